@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"regexp"
+	"strconv"
 
 	"github.com/lib/pq"
 )
@@ -85,41 +86,125 @@ func IsSerializationFailure(err error) bool {
 }
 
 func extractConstraint(err error) string {
-	{ // pq
+	if e, ok := AsError(err); ok {
+		return e.Constraint
+	}
+	return ""
+}
+
+// Error is the database error, normalized from driver's error
+type Error struct {
+	Code       string
+	Severity   string
+	Message    string
+	Detail     string
+	Hint       string
+	Schema     string
+	Table      string
+	Column     string
+	Constraint string
+	Position   int // 1-based position in query, 0 if not available
+	Where      string
+}
+
+func (e *Error) Error() string {
+	return "pgsql: " + e.Message + " (SQLSTATE " + e.Code + ")"
+}
+
+// SQLState returns error code
+func (e *Error) SQLState() string {
+	return e.Code
+}
+
+// AsError converts pq, pgx or CockroachDB error into Error
+func AsError(err error) (*Error, bool) {
+	{ // pgsql
+		var pgErr *Error
+		if errors.As(err, &pgErr) {
+			return pgErr, true
+		}
+	}
+
+	{ // pq, cockroachdb
 		var pqErr *pq.Error
 		if errors.As(err, &pqErr) {
-			if pqErr.Constraint != "" {
-				return pqErr.Constraint
-			}
-			if pqErr.Message == "" {
-				return ""
-			}
-			if s := extractCRDBKey(pqErr.Message); s != "" {
-				return s
-			}
-			if s := extractLastQuote(pqErr.Message); s != "" {
-				return s
-			}
-			return ""
+			pos, _ := strconv.Atoi(pqErr.Position)
+			return &Error{
+				Code:       string(pqErr.Code),
+				Severity:   pqErr.Severity,
+				Message:    pqErr.Message,
+				Detail:     pqErr.Detail,
+				Hint:       pqErr.Hint,
+				Schema:     pqErr.Schema,
+				Table:      pqErr.Table,
+				Column:     pqErr.Column,
+				Constraint: extractPQConstraint(pqErr),
+				Position:   pos,
+				Where:      pqErr.Where,
+			}, true
 		}
 	}
 
 	{ // pgx
-		v := reflect.ValueOf(err)
+		var sErr sqlState
+		if !errors.As(err, &sErr) {
+			return nil, false
+		}
+		v := reflect.ValueOf(sErr)
 		if v.Kind() == reflect.Ptr {
 			v = v.Elem()
 		}
 		if v.Kind() != reflect.Struct {
-			return ""
-		}
-		if f := v.FieldByName("ConstraintName"); f.IsValid() {
-			return f.String()
+			return &Error{Code: sErr.SQLState(), Message: err.Error()}, true
 		}
+		return &Error{
+			Code:       sErr.SQLState(),
+			Severity:   fieldString(v, "Severity"),
+			Message:    fieldString(v, "Message"),
+			Detail:     fieldString(v, "Detail"),
+			Hint:       fieldString(v, "Hint"),
+			Schema:     fieldString(v, "SchemaName"),
+			Table:      fieldString(v, "TableName"),
+			Column:     fieldString(v, "ColumnName"),
+			Constraint: fieldString(v, "ConstraintName"),
+			Position:   int(fieldInt(v, "Position")),
+			Where:      fieldString(v, "Where"),
+		}, true
 	}
+}
 
+// extractPQConstraint extracts constraint from pq error,
+// cockroachdb does not send constraint, extract it from message
+func extractPQConstraint(pqErr *pq.Error) string {
+	if pqErr.Constraint != "" {
+		return pqErr.Constraint
+	}
+	if pqErr.Message == "" {
+		return ""
+	}
+	if s := extractCRDBKey(pqErr.Message); s != "" {
+		return s
+	}
+	return extractLastQuote(pqErr.Message)
+}
+
+func fieldString(v reflect.Value, name string) string {
+	if f := v.FieldByName(name); f.IsValid() && f.Kind() == reflect.String {
+		return f.String()
+	}
 	return ""
 }
 
+func fieldInt(v reflect.Value, name string) int64 {
+	if f := v.FieldByName(name); f.IsValid() {
+		switch f.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return f.Int()
+		}
+	}
+	return 0
+}
+
 var reLastQuoteExtractor = regexp.MustCompile(`"([^"]*)"[^"]*$`)
 
 // extractLastQuote extracts last string in quote
@@ -2,6 +2,7 @@ package pgsql_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -123,3 +124,111 @@ func TestIsQueryCanceled(t *testing.T) {
 	_, err := db.ExecContext(ctx, "select pg_sleep(1)")
 	assert.True(t, pgsql.IsQueryCanceled(err))
 }
+
+type pgxPgError struct {
+	Severity       string
+	Code           string
+	Message        string
+	Detail         string
+	Hint           string
+	Position       int32
+	Where          string
+	SchemaName     string
+	TableName      string
+	ColumnName     string
+	ConstraintName string
+}
+
+func (e *pgxPgError) Error() string {
+	return e.Severity + ": " + e.Message + " (SQLSTATE " + e.Code + ")"
+}
+
+func (e *pgxPgError) SQLState() string {
+	return e.Code
+}
+
+func TestAsError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pq", func(t *testing.T) {
+		err := fmt.Errorf("create user; %w", &pq.Error{
+			Severity:   "ERROR",
+			Code:       "23505",
+			Message:    `duplicate key value violates unique constraint "users_email_key"`,
+			Detail:     "Key (email)=(a@example.com) already exists.",
+			Schema:     "public",
+			Table:      "users",
+			Constraint: "users_email_key",
+			Position:   "12",
+		})
+
+		e, ok := pgsql.AsError(err)
+		if assert.True(t, ok) {
+			assert.Equal(t, &pgsql.Error{
+				Code:       "23505",
+				Severity:   "ERROR",
+				Message:    `duplicate key value violates unique constraint "users_email_key"`,
+				Detail:     "Key (email)=(a@example.com) already exists.",
+				Schema:     "public",
+				Table:      "users",
+				Constraint: "users_email_key",
+				Position:   12,
+			}, e)
+		}
+	})
+
+	t.Run("pgx", func(t *testing.T) {
+		err := fmt.Errorf("create user; %w", &pgxPgError{
+			Severity:       "ERROR",
+			Code:           "23502",
+			Message:        `null value in column "name" violates not-null constraint`,
+			Detail:         "Failing row contains (1, null).",
+			Position:       5,
+			SchemaName:     "public",
+			TableName:      "users",
+			ColumnName:     "name",
+			ConstraintName: "",
+		})
+
+		e, ok := pgsql.AsError(err)
+		if assert.True(t, ok) {
+			assert.Equal(t, &pgsql.Error{
+				Code:     "23502",
+				Severity: "ERROR",
+				Message:  `null value in column "name" violates not-null constraint`,
+				Detail:   "Failing row contains (1, null).",
+				Schema:   "public",
+				Table:    "users",
+				Column:   "name",
+				Position: 5,
+			}, e)
+		}
+	})
+
+	t.Run("cockroachdb", func(t *testing.T) {
+		e, ok := pgsql.AsError(&pq.Error{
+			Severity: "ERROR",
+			Code:     "23503",
+			Message:  "foreign key violation: value ['b'] not found in a@primary [id] (txn=e3f9af56-5f73-4899-975c-4bb1de800402)",
+		})
+		if assert.True(t, ok) {
+			assert.Equal(t, "23503", e.Code)
+			assert.Equal(t, "a@primary", e.Constraint)
+		}
+	})
+
+	t.Run("not database error", func(t *testing.T) {
+		e, ok := pgsql.AsError(fmt.Errorf("some error"))
+		assert.False(t, ok)
+		assert.Nil(t, e)
+
+		e, ok = pgsql.AsError(nil)
+		assert.False(t, ok)
+		assert.Nil(t, e)
+	})
+
+	t.Run("error code", func(t *testing.T) {
+		e, _ := pgsql.AsError(&pgxError{Code: "23505", ConstraintName: "users_email_key"})
+		assert.True(t, pgsql.IsUniqueViolation(e, "users_email_key"))
+	})
+}
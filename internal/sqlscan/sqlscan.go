@@ -0,0 +1,123 @@
+// Package sqlscan scans sql query text for placeholders.
+package sqlscan
+
+import "strings"
+
+// Skip returns index after the token starts at i that must not be rewritten
+// (string literal, quoted identifier, comment or dollar quoted string),
+// or i if there is no such token at i.
+func Skip(s string, i int) int {
+	switch c := s[i]; {
+	case c == '\'':
+		return skipQuoted(s, i, isEscapeString(s, i))
+	case c == '"':
+		return skipQuoted(s, i, false)
+	case c == '-' && strings.HasPrefix(s[i:], "--"):
+		j := strings.IndexByte(s[i:], '\n')
+		if j < 0 {
+			return len(s)
+		}
+		return i + j
+	case c == '/' && strings.HasPrefix(s[i:], "/*"):
+		return skipComment(s, i)
+	case c == '$':
+		return skipDollarQuoted(s, i)
+	}
+	return i
+}
+
+// Placeholder returns number of $n placeholder starts at i and index after it,
+// ok is false if there is no placeholder at i
+func Placeholder(s string, i int) (n, end int, ok bool) {
+	if s[i] != '$' || i > 0 && IsNameChar(s[i-1], false) {
+		return 0, i, false
+	}
+	j := i + 1
+	for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+		n = n*10 + int(s[j]-'0')
+		j++
+	}
+	if j == i+1 {
+		return 0, i, false
+	}
+	return n, j, true
+}
+
+// IsNameChar checks is c can be part of unquoted name
+func IsNameChar(c byte, first bool) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_', c >= 0x80:
+		return true
+	case c >= '0' && c <= '9', c == '$':
+		return !first
+	}
+	return false
+}
+
+// isEscapeString checks is string literal at i an escape string (E'...')
+func isEscapeString(s string, i int) bool {
+	if i == 0 || (s[i-1] != 'e' && s[i-1] != 'E') {
+		return false
+	}
+	return i == 1 || !IsNameChar(s[i-2], false)
+}
+
+// skipQuoted returns index after quoted string starts at i
+func skipQuoted(s string, i int, backslash bool) int {
+	q := s[i]
+	for j := i + 1; j < len(s); j++ {
+		if backslash && s[j] == '\\' {
+			j++
+			continue
+		}
+		if s[j] != q {
+			continue
+		}
+		if j+1 < len(s) && s[j+1] == q { // escaped quote
+			j++
+			continue
+		}
+		return j + 1
+	}
+	return len(s)
+}
+
+// skipComment returns index after block comment starts at i, block comments can be nested
+func skipComment(s string, i int) int {
+	depth := 0
+	for j := i; j+1 < len(s); j++ {
+		switch {
+		case s[j] == '/' && s[j+1] == '*':
+			depth++
+			j++
+		case s[j] == '*' && s[j+1] == '/':
+			depth--
+			j++
+			if depth == 0 {
+				return j + 1
+			}
+		}
+	}
+	return len(s)
+}
+
+// skipDollarQuoted returns index after dollar quoted string ($tag$...$tag$) starts at i,
+// or i if not dollar quoted string
+func skipDollarQuoted(s string, i int) int {
+	if i > 0 && IsNameChar(s[i-1], false) { // part of identifier, ex. a$b$
+		return i
+	}
+	j := i + 1
+	for j < len(s) && s[j] != '$' && IsNameChar(s[j], j == i+1) {
+		j++
+	}
+	if j >= len(s) || s[j] != '$' {
+		return i
+	}
+	tag := s[i : j+1]
+	k := strings.Index(s[j+1:], tag)
+	if k < 0 {
+		return len(s)
+	}
+	return j + 1 + k + len(tag)
+}
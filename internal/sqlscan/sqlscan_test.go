@@ -0,0 +1,56 @@
+package sqlscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/internal/sqlscan"
+)
+
+func TestSkip(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		s    string
+		i    int
+		want int
+	}{
+		{"'a''b' x", 0, 6},
+		{`"a""b" x`, 0, 6},
+		{`E'a\'b' x`, 1, 7},
+		{`e'a\'b' x`, 1, 7},
+		{`name'a\' x`, 4, 8},
+		{"-- a\nx", 0, 4},
+		{"-- a", 0, 4},
+		{"/* a /* b */ c */ x", 0, 17},
+		{"/* a", 0, 4},
+		{"$$ a $$ x", 0, 7},
+		{"$tag$ $$ $tag$ x", 0, 14},
+		{"$1 x", 0, 0},
+		{"a$b$ x", 1, 1},
+		{"x", 0, 0},
+		{"-x", 0, 0},
+	}
+
+	for _, tC := range cases {
+		t.Run(tC.s, func(t *testing.T) {
+			assert.Equal(t, tC.want, sqlscan.Skip(tC.s, tC.i))
+		})
+	}
+}
+
+func TestPlaceholder(t *testing.T) {
+	t.Parallel()
+
+	n, end, ok := sqlscan.Placeholder("$12 x", 0)
+	assert.True(t, ok)
+	assert.Equal(t, 12, n)
+	assert.Equal(t, 3, end)
+
+	_, _, ok = sqlscan.Placeholder("$x", 0)
+	assert.False(t, ok)
+
+	_, _, ok = sqlscan.Placeholder("a$1", 1)
+	assert.False(t, ok)
+}
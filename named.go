@@ -0,0 +1,165 @@
+package pgsql
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/acoshift/pgsql/internal/sqlscan"
+)
+
+// NamedQuery rewrites named parameters (:name or @name) in query
+// into positional parameters ($n) and binds values from arg.
+//
+// The same name used many times will be bind into a single $n.
+// String literals, quoted identifiers, comments and :: casts are skipped.
+//
+// see BindNamed for supported arg types.
+func NamedQuery(query string, arg any) (string, []any, error) {
+	var (
+		b     strings.Builder
+		names []string
+		index = map[string]int{}
+	)
+
+	for i := 0; i < len(query); {
+		c := query[i]
+
+		if j := sqlscan.Skip(query, i); j > i {
+			b.WriteString(query[i:j])
+			i = j
+			continue
+		}
+
+		switch {
+		case c == ':' && strings.HasPrefix(query[i:], "::"):
+			b.WriteString("::")
+			i += 2
+		case (c == ':' || c == '@') && i+1 < len(query) && isNameChar(query[i+1], true):
+			j := i + 1
+			for j < len(query) && isNameChar(query[j], false) {
+				j++
+			}
+			name := query[i+1 : j]
+			n, ok := index[name]
+			if !ok {
+				names = append(names, name)
+				n = len(names)
+				index[name] = n
+			}
+			b.WriteString("$" + strconv.Itoa(n))
+			i = j
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	args, err := BindNamed(names, arg)
+	if err != nil {
+		return "", nil, err
+	}
+	return b.String(), args, nil
+}
+
+// isNameChar checks is c can be part of parameter name,
+// parameter name is unquoted name without $ and non-ascii characters,
+// ex. :name followed by non-ascii character or $ ends the name
+func isNameChar(c byte, first bool) bool {
+	return c != '$' && c < 0x80 && sqlscan.IsNameChar(c, first)
+}
+
+// BindNamed returns values for names from arg.
+//
+// arg can be map[string]T or struct (or pointer to struct),
+// struct fields are matched by `db` tag or case-insensitive field name.
+func BindNamed(names []string, arg any) ([]any, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	lookup, err := namedLookup(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]any, len(names))
+	for i, name := range names {
+		v, ok := lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("pgsql: named argument %q not found", name)
+		}
+		args[i] = v
+	}
+	return args, nil
+}
+
+func namedLookup(arg any) (func(name string) (any, bool), error) {
+	if m, ok := arg.(map[string]any); ok {
+		return func(name string) (any, bool) {
+			v, ok := m[name]
+			return v, ok
+		}, nil
+	}
+
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, fmt.Errorf("pgsql: named argument is nil")
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("pgsql: named argument map key must be string")
+		}
+		return func(name string) (any, bool) {
+			x := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if !x.IsValid() {
+				return nil, false
+			}
+			return x.Interface(), true
+		}, nil
+	case reflect.Struct:
+		return func(name string) (any, bool) {
+			x, ok := structField(v, name)
+			if !ok {
+				return nil, false
+			}
+			return x.Interface(), true
+		}, nil
+	}
+	return nil, fmt.Errorf("pgsql: named argument type %T not supported", arg)
+}
+
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+
+	// tag has priority over field name
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.IsExported() && f.Tag.Get("db") == name {
+			return v.Field(i), true
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("db") == "-" {
+			continue
+		}
+		// exported fields of embedded struct are accessible even if struct is not exported
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if x, ok := structField(v.Field(i), name); ok {
+				return x, true
+			}
+			continue
+		}
+		if f.IsExported() && f.Tag.Get("db") == "" && strings.EqualFold(f.Name, name) {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,57 @@
+package pgsql_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql"
+)
+
+func TestNamedQuery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("map", func(t *testing.T) {
+		q, args, err := pgsql.NamedQuery(`
+			select id::text, ':skip', "@skip" -- :skip
+			from users /* @skip */
+			where id = :id and (owner = @user or created_by = :user) and body = $$ :skip $$
+		`, map[string]any{
+			"id":   1,
+			"user": "u",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `
+			select id::text, ':skip', "@skip" -- :skip
+			from users /* @skip */
+			where id = $1 and (owner = $2 or created_by = $2) and body = $$ :skip $$
+		`, q)
+		assert.Equal(t, []any{1, "u"}, args)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type base struct {
+			ID int64
+		}
+		arg := struct {
+			base
+			Name  string
+			Email string `db:"mail"`
+		}{base{1}, "name", "email"}
+
+		q, args, err := pgsql.NamedQuery(`update users set name = :name, email = :mail where id = :id`, &arg)
+		assert.NoError(t, err)
+		assert.Equal(t, `update users set name = $1, email = $2 where id = $3`, q)
+		assert.Equal(t, []any{"name", "email", int64(1)}, args)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, _, err := pgsql.NamedQuery(`select :id`, map[string]any{})
+		assert.Error(t, err)
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		_, _, err := pgsql.NamedQuery(`select :id`, 1)
+		assert.Error(t, err)
+	})
+}
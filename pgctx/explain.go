@@ -0,0 +1,229 @@
+package pgctx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/acoshift/pgsql"
+)
+
+// Statement is the built statement, ex. *pgstmt.Result.
+//
+// Statement might implement Err() error to report build error,
+// and SQLContext(ctx) (query string, args []any, err error)
+// to build query from context, ex. apply row scope.
+type Statement interface {
+	SQL() (query string, args []any)
+}
+
+type errStatement interface {
+	Err() error
+}
+
+type contextStatement interface {
+	SQLContext(ctx context.Context) (query string, args []any, err error)
+}
+
+// statementSQL returns query and arguments of stmt
+func statementSQL(ctx context.Context, stmt Statement) (query string, args []any, err error) {
+	if x, ok := stmt.(contextStatement); ok {
+		return x.SQLContext(ctx)
+	}
+	if x, ok := stmt.(errStatement); ok {
+		if err := x.Err(); err != nil {
+			return "", nil, err
+		}
+	}
+	query, args = stmt.SQL()
+	return query, args, nil
+}
+
+// ExplainOptions is the explain options
+type ExplainOptions struct {
+	Analyze  bool // executes the statement inside tx (or savepoint) that will be rolled back
+	Buffers  bool
+	Verbose  bool
+	Settings bool
+	WAL      bool
+}
+
+// ExplainResult is the parsed explain output
+type ExplainResult struct {
+	Plan          *ExplainPlan `json:"Plan"`
+	PlanningTime  float64      `json:"Planning Time"`  // milliseconds, analyze only
+	ExecutionTime float64      `json:"Execution Time"` // milliseconds, analyze only
+
+	// Raw is the json output from database
+	Raw json.RawMessage `json:"-"`
+}
+
+// ExplainPlan is the plan node
+type ExplainPlan struct {
+	NodeType     string `json:"Node Type"`
+	RelationName string `json:"Relation Name"`
+	Schema       string `json:"Schema"`
+	Alias        string `json:"Alias"`
+	IndexName    string `json:"Index Name"`
+	JoinType     string `json:"Join Type"`
+	Filter       string `json:"Filter"`
+	IndexCond    string `json:"Index Cond"`
+
+	StartupCost float64 `json:"Startup Cost"`
+	TotalCost   float64 `json:"Total Cost"`
+	PlanRows    float64 `json:"Plan Rows"`
+	PlanWidth   int     `json:"Plan Width"`
+
+	// analyze only
+	ActualStartupTime   float64 `json:"Actual Startup Time"` // milliseconds
+	ActualTotalTime     float64 `json:"Actual Total Time"`   // milliseconds
+	ActualRows          float64 `json:"Actual Rows"`
+	ActualLoops         float64 `json:"Actual Loops"`
+	RowsRemovedByFilter float64 `json:"Rows Removed by Filter"`
+
+	// buffers only
+	SharedHitBlocks  int64 `json:"Shared Hit Blocks"`
+	SharedReadBlocks int64 `json:"Shared Read Blocks"`
+
+	Plans []*ExplainPlan `json:"Plans"`
+}
+
+// TotalCost returns estimated total cost of the statement
+func (r *ExplainResult) TotalCost() float64 {
+	if r.Plan == nil {
+		return 0
+	}
+	return r.Plan.TotalCost
+}
+
+// ActualTime returns actual total time of the statement in milliseconds, analyze only
+func (r *ExplainResult) ActualTime() float64 {
+	if r.Plan == nil {
+		return 0
+	}
+	return r.Plan.ActualTotalTime
+}
+
+// SeqScans returns all sequential scan nodes
+func (r *ExplainResult) SeqScans() []*ExplainPlan {
+	var xs []*ExplainPlan
+	r.Walk(func(p *ExplainPlan) {
+		if p.IsSeqScan() {
+			xs = append(xs, p)
+		}
+	})
+	return xs
+}
+
+// Walk calls f for all plan nodes, depth-first
+func (r *ExplainResult) Walk(f func(p *ExplainPlan)) {
+	if r.Plan != nil {
+		r.Plan.Walk(f)
+	}
+}
+
+// Walk calls f for p and all its child nodes, depth-first
+func (p *ExplainPlan) Walk(f func(p *ExplainPlan)) {
+	f(p)
+	for _, x := range p.Plans {
+		x.Walk(f)
+	}
+}
+
+// IsSeqScan checks is node a sequential scan
+func (p *ExplainPlan) IsSeqScan() bool {
+	return p.NodeType == "Seq Scan"
+}
+
+// RowsEstimateRatio returns actual rows divided by estimated rows, analyze only.
+//
+// Greater than 1 means rows are underestimated, less than 1 means overestimated.
+func (p *ExplainPlan) RowsEstimateRatio() float64 {
+	return math.Max(p.ActualRows, 1) / math.Max(p.PlanRows, 1)
+}
+
+// Explain runs explain for the statement and returns parsed plan
+func Explain(ctx context.Context, stmt Statement, opts *ExplainOptions) (*ExplainResult, error) {
+	if opts == nil {
+		opts = &ExplainOptions{}
+	}
+
+	query, args, err := statementSQL(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	explainQuery := "explain (" + strings.Join(opts.options(), ", ") + ") " + query
+
+	var raw []byte
+	run := func(ctx context.Context) error {
+		return QueryRow(ctx, explainQuery, args...).Scan(&raw)
+	}
+
+	if opts.Analyze {
+		// any statement might modify data (ex. select that calls function),
+		// always rollback the executed statement
+		err = runAndRollback(ctx, run)
+	} else {
+		err = run(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rs []*ExplainResult
+	err = json.Unmarshal(raw, &rs)
+	if err != nil {
+		return nil, fmt.Errorf("pgctx: can not parse explain output; %w", err)
+	}
+	if len(rs) == 0 {
+		return nil, fmt.Errorf("pgctx: empty explain output")
+	}
+	r := rs[0]
+	r.Raw = raw
+	return r, nil
+}
+
+func (opts *ExplainOptions) options() []string {
+	xs := []string{"format json"}
+	if opts.Analyze {
+		xs = append(xs, "analyze")
+	}
+	if opts.Buffers {
+		xs = append(xs, "buffers")
+	}
+	if opts.Verbose {
+		xs = append(xs, "verbose")
+	}
+	if opts.Settings {
+		xs = append(xs, "settings")
+	}
+	if opts.WAL {
+		xs = append(xs, "wal")
+	}
+	return xs
+}
+
+// runAndRollback calls f inside tx (or savepoint if already in tx) then rollbacks
+func runAndRollback(ctx context.Context, f func(ctx context.Context) error) error {
+	if IsInTx(ctx) {
+		_, err := Exec(ctx, "savepoint pgctx_explain")
+		if err != nil {
+			return err
+		}
+		err = f(ctx)
+		if _, rbErr := Exec(ctx, "rollback to savepoint pgctx_explain"); err == nil {
+			err = rbErr
+		}
+		return err
+	}
+
+	return RunInTx(ctx, func(ctx context.Context) error {
+		err := f(ctx)
+		if err != nil {
+			return err
+		}
+		return pgsql.ErrAbortTx
+	})
+}
@@ -0,0 +1,189 @@
+package pgctx_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgctx"
+)
+
+type statement struct {
+	query string
+	args  []any
+}
+
+func (s statement) SQL() (string, []any) {
+	return s.query, s.args
+}
+
+type errStatement struct {
+	statement
+	err error
+}
+
+func (s errStatement) Err() error {
+	return s.err
+}
+
+type ctxKeyTenant struct{}
+
+type contextStatement struct {
+	statement
+}
+
+func (s contextStatement) SQLContext(ctx context.Context) (string, []any, error) {
+	tenant, ok := ctx.Value(ctxKeyTenant{}).(int)
+	if !ok {
+		return "", nil, fmt.Errorf("tenant required")
+	}
+	return s.query + " where tenant_id = $1", []any{tenant}, nil
+}
+
+const explainOutput = `[{
+	"Plan": {
+		"Node Type": "Nested Loop",
+		"Join Type": "Inner",
+		"Startup Cost": 0.29,
+		"Total Cost": 120.5,
+		"Plan Rows": 10,
+		"Plan Width": 16,
+		"Actual Startup Time": 0.02,
+		"Actual Total Time": 1.5,
+		"Actual Rows": 100,
+		"Actual Loops": 1,
+		"Plans": [
+			{
+				"Node Type": "Seq Scan",
+				"Relation Name": "users",
+				"Alias": "u",
+				"Filter": "(status = 'active'::text)",
+				"Total Cost": 100,
+				"Plan Rows": 10,
+				"Actual Rows": 100,
+				"Actual Loops": 1,
+				"Rows Removed by Filter": 900
+			},
+			{
+				"Node Type": "Index Scan",
+				"Relation Name": "orders",
+				"Index Name": "orders_user_id_idx",
+				"Total Cost": 2,
+				"Plan Rows": 1,
+				"Actual Rows": 1,
+				"Actual Loops": 100
+			}
+		]
+	},
+	"Planning Time": 0.1,
+	"Execution Time": 1.6
+}]`
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Explain", func(t *testing.T) {
+		ctx, mock := newCtx(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("explain (format json) select * from users where id = $1")).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(explainOutput))
+
+		r, err := pgctx.Explain(ctx, statement{"select * from users where id = $1", []any{1}}, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		assert.Equal(t, 120.5, r.TotalCost())
+		assert.Equal(t, 1.5, r.ActualTime())
+		assert.Equal(t, 1.6, r.ExecutionTime)
+		assert.Equal(t, 10.0, r.Plan.RowsEstimateRatio())
+		if seqScans := r.SeqScans(); assert.Len(t, seqScans, 1) {
+			assert.Equal(t, "users", seqScans[0].RelationName)
+			assert.Equal(t, 900.0, seqScans[0].RowsRemovedByFilter)
+		}
+		assert.JSONEq(t, explainOutput, string(r.Raw))
+	})
+
+	t.Run("Analyze select", func(t *testing.T) {
+		ctx, mock := newCtx(t)
+
+		// select might call function that modifies data
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("explain (format json, analyze, buffers) select create_user()")).
+			WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(explainOutput))
+		mock.ExpectRollback()
+
+		_, err := pgctx.Explain(ctx, statement{query: "select create_user()"}, &pgctx.ExplainOptions{
+			Analyze: true,
+			Buffers: true,
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Analyze modifying statement", func(t *testing.T) {
+		ctx, mock := newCtx(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("explain (format json, analyze) update users set name = $1")).
+			WithArgs("test").
+			WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(explainOutput))
+		mock.ExpectRollback()
+
+		_, err := pgctx.Explain(ctx, statement{"update users set name = $1", []any{"test"}}, &pgctx.ExplainOptions{
+			Analyze: true,
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Analyze modifying statement inside Tx", func(t *testing.T) {
+		ctx, mock := newCtx(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("savepoint pgctx_explain").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta("explain (format json, analyze) delete from users")).
+			WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(explainOutput))
+		mock.ExpectExec("rollback to savepoint pgctx_explain").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := pgctx.RunInTx(ctx, func(ctx context.Context) error {
+			_, err := pgctx.Explain(ctx, statement{query: "delete from users"}, &pgctx.ExplainOptions{
+				Analyze: true,
+			})
+			return err
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Build error", func(t *testing.T) {
+		ctx, mock := newCtx(t)
+
+		buildErr := fmt.Errorf("build error")
+		_, err := pgctx.Explain(ctx, errStatement{statement{query: "delete from users"}, buildErr}, &pgctx.ExplainOptions{
+			Analyze: true,
+		})
+		assert.Equal(t, buildErr, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Statement from context", func(t *testing.T) {
+		ctx, mock := newCtx(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("explain (format json) select * from users where tenant_id = $1")).
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(explainOutput))
+
+		stmt := contextStatement{statement{query: "select * from users"}}
+		_, err := pgctx.Explain(ctx, stmt, nil)
+		assert.Error(t, err)
+		_, err = pgctx.Explain(context.WithValue(ctx, ctxKeyTenant{}, 7), stmt, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -140,6 +140,19 @@ type (
 )
 
 func q(ctx context.Context) Queryer {
+	if c, ok := ctx.Value(ctxKeyStmtCache{}).(*StmtCache); ok {
+		x := stmtCacheQueryer{
+			cache: c,
+			db:    ctx.Value(ctxKeyDB{}).(Queryer),
+		}
+		x.Queryer = x.db
+		if tx, ok := ctx.Value(ctxKeyQueryer{}).(*wrapTx); ok {
+			x.Queryer = tx
+			x.tx = tx.Tx
+		}
+		return &x
+	}
+
 	if q, ok := ctx.Value(ctxKeyQueryer{}).(Queryer); ok {
 		return q
 	}
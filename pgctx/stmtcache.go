@@ -0,0 +1,265 @@
+package pgctx
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"net/http"
+	"sync"
+)
+
+const defaultStmtCacheSize = 100
+
+// StmtCache is the LRU prepared statement cache keyed by db and query.
+//
+// Register cache into context using NewStmtCacheContext or StmtCacheMiddleware,
+// then QueryRow, Query, Exec and Iter will transparently use prepared statements.
+//
+// Inside tx, statement not in cache runs without prepare,
+// and is prepared on db in background when other connection is available.
+// Cached statement is prepared once on each tx's connection.
+type StmtCache struct {
+	mu        sync.Mutex
+	size      int
+	ll        *list.List
+	items     map[stmtCacheKey]*list.Element
+	filling   map[stmtCacheKey]bool
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// StmtCacheStats is the statistics of StmtCache
+type StmtCacheStats struct {
+	Size      int
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+type stmtCacheKey struct {
+	db    any
+	query string
+}
+
+type cachedStmt struct {
+	key     stmtCacheKey
+	stmt    *sql.Stmt
+	refs    int
+	evicted bool
+}
+
+// NewStmtCache creates new statement cache,
+// size is the maximum number of prepared statements, default to 100
+func NewStmtCache(size int) *StmtCache {
+	if size <= 0 {
+		size = defaultStmtCacheSize
+	}
+	return &StmtCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[stmtCacheKey]*list.Element),
+	}
+}
+
+// Stats returns cache statistics
+func (c *StmtCache) Stats() StmtCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return StmtCacheStats{
+		Size:      c.ll.Len(),
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+}
+
+// Close closes all cached statements
+func (c *StmtCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var err error
+	for c.ll.Len() > 0 {
+		if e := c.evict(c.ll.Back()); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// get returns cached statement without preparing
+func (c *StmtCache) get(db Queryer, query string) (*cachedStmt, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[stmtCacheKey{db, query}]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.ll.MoveToFront(e)
+	s := e.Value.(*cachedStmt)
+	s.refs++
+	return s, true
+}
+
+func (c *StmtCache) acquire(ctx context.Context, db Queryer, query string) (*cachedStmt, error) {
+	if s, ok := c.get(db, query); ok {
+		return s, nil
+	}
+	return c.prepare(ctx, db, query)
+}
+
+// fill prepares statement on db in background,
+// for query inside tx that can not wait for other connection
+func (c *StmtCache) fill(db Queryer, query string) {
+	key := stmtCacheKey{db, query}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.filling[key] {
+		return
+	}
+	if c.filling == nil {
+		c.filling = make(map[stmtCacheKey]bool)
+	}
+	c.filling[key] = true
+
+	go func() {
+		s, err := c.prepare(context.Background(), db, query)
+		if err == nil {
+			c.release(s)
+		}
+
+		c.mu.Lock()
+		delete(c.filling, key)
+		c.mu.Unlock()
+	}()
+}
+
+// prepare prepares statement on db and adds into cache
+func (c *StmtCache) prepare(ctx context.Context, db Queryer, query string) (*cachedStmt, error) {
+	key := stmtCacheKey{db, query}
+	stmt, err := db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// other goroutine might prepared the same query
+	if e, ok := c.items[key]; ok {
+		stmt.Close()
+		c.ll.MoveToFront(e)
+		s := e.Value.(*cachedStmt)
+		s.refs++
+		return s, nil
+	}
+
+	s := &cachedStmt{key: key, stmt: stmt, refs: 1}
+	c.items[key] = c.ll.PushFront(s)
+	for c.ll.Len() > c.size {
+		c.evict(c.ll.Back())
+		c.evictions++
+	}
+	return s, nil
+}
+
+func (c *StmtCache) release(s *cachedStmt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s.refs--
+	if s.evicted && s.refs == 0 {
+		s.stmt.Close()
+	}
+}
+
+// evict removes element from cache, must hold lock
+func (c *StmtCache) evict(e *list.Element) error {
+	s := c.ll.Remove(e).(*cachedStmt)
+	delete(c.items, s.key)
+	s.evicted = true
+	if s.refs == 0 {
+		return s.stmt.Close()
+	}
+	return nil
+}
+
+// NewStmtCacheContext creates new context with statement cache
+func NewStmtCacheContext(ctx context.Context, c *StmtCache) context.Context {
+	return context.WithValue(ctx, ctxKeyStmtCache{}, c)
+}
+
+// StmtCacheMiddleware injects statement cache into request's context
+func StmtCacheMiddleware(c *StmtCache) func(h http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r = r.WithContext(NewStmtCacheContext(r.Context(), c))
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+type ctxKeyStmtCache struct{}
+
+// stmtCacheQueryer executes queries using cached prepared statements,
+// fallback to queryer when statement can not be prepared,
+// or statement is not cached inside transaction
+type stmtCacheQueryer struct {
+	Queryer
+	cache *StmtCache
+	db    Queryer
+	tx    *sql.Tx
+}
+
+func (q *stmtCacheQueryer) stmt(ctx context.Context, query string) (*sql.Stmt, *cachedStmt, bool) {
+	if q.tx != nil {
+		// preparing on db needs other connection while tx holds one,
+		// use only cached statement, tx prepares it on tx's connection
+		s, ok := q.cache.get(q.db, query)
+		if !ok {
+			q.cache.fill(q.db, query)
+			return nil, nil, false
+		}
+		return q.tx.StmtContext(ctx, s.stmt), s, true
+	}
+
+	s, err := q.cache.acquire(ctx, q.db, query)
+	if err != nil {
+		return nil, nil, false
+	}
+	return s.stmt, s, true
+}
+
+func (q *stmtCacheQueryer) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	stmt, s, ok := q.stmt(ctx, query)
+	if !ok {
+		return q.Queryer.QueryRowContext(ctx, query, args...)
+	}
+	defer q.cache.release(s)
+	return stmt.QueryRowContext(ctx, args...)
+}
+
+func (q *stmtCacheQueryer) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	stmt, s, ok := q.stmt(ctx, query)
+	if !ok {
+		return q.Queryer.QueryContext(ctx, query, args...)
+	}
+	defer q.cache.release(s)
+	return stmt.QueryContext(ctx, args...)
+}
+
+func (q *stmtCacheQueryer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	stmt, s, ok := q.stmt(ctx, query)
+	if !ok {
+		return q.Queryer.ExecContext(ctx, query, args...)
+	}
+	defer q.cache.release(s)
+	return stmt.ExecContext(ctx, args...)
+}
@@ -0,0 +1,170 @@
+package pgctx_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgctx"
+)
+
+func TestStmtCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Cache hit", func(t *testing.T) {
+		ctx, mock := newCtx(t)
+		c := pgctx.NewStmtCache(10)
+		ctx = pgctx.NewStmtCacheContext(ctx, c)
+
+		p := mock.ExpectPrepare("select 1")
+		p.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+		p.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+
+		_, err := pgctx.Exec(ctx, "select 1")
+		assert.NoError(t, err)
+		_, err = pgctx.Exec(ctx, "select 1")
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, pgctx.StmtCacheStats{Size: 1, Hits: 1, Misses: 1}, c.Stats())
+	})
+
+	t.Run("Eviction", func(t *testing.T) {
+		ctx, mock := newCtx(t)
+		c := pgctx.NewStmtCache(1)
+		ctx = pgctx.NewStmtCacheContext(ctx, c)
+
+		mock.ExpectPrepare("select 1").
+			WillBeClosed().
+			ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectPrepare("select 2").
+			ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+
+		_, err := pgctx.Exec(ctx, "select 1")
+		assert.NoError(t, err)
+		_, err = pgctx.Exec(ctx, "select 2")
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, pgctx.StmtCacheStats{Size: 1, Misses: 2, Evictions: 1}, c.Stats())
+	})
+
+	t.Run("Fallback when prepare failed", func(t *testing.T) {
+		ctx, mock := newCtx(t)
+		c := pgctx.NewStmtCache(10)
+		ctx = pgctx.NewStmtCacheContext(ctx, c)
+
+		mock.ExpectPrepare("select 1").WillReturnError(fmt.Errorf("prepare error"))
+		mock.ExpectExec("select 1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		_, err := pgctx.Exec(ctx, "select 1")
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, 0, c.Stats().Size)
+	})
+
+	t.Run("Inside Tx", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		// tx holds the only connection, statement is prepared after tx ends
+		db.SetMaxOpenConns(1)
+		ctx := pgctx.NewContext(context.Background(), db)
+		c := pgctx.NewStmtCache(10)
+		ctx = pgctx.NewStmtCacheContext(ctx, c)
+		ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+
+		p := mock.ExpectPrepare("select 1")
+		p.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectBegin()
+		// tx uses cached statement
+		p.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+		// statement not in cache is not prepared inside tx
+		mock.ExpectExec("select 2").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+		// then prepared in background
+		mock.ExpectPrepare("select 2")
+
+		_, err = pgctx.Exec(ctx, "select 1")
+		assert.NoError(t, err)
+		err = pgctx.RunInTx(ctx, func(ctx context.Context) error {
+			_, err := pgctx.Exec(ctx, "select 1")
+			if err != nil {
+				return err
+			}
+			_, err = pgctx.Exec(ctx, "select 2")
+			return err
+		})
+		assert.NoError(t, err)
+
+		assert.Eventually(t, func() bool { return c.Stats().Size == 2 }, time.Second, time.Millisecond)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, pgctx.StmtCacheStats{Size: 2, Hits: 1, Misses: 2}, c.Stats())
+	})
+
+	t.Run("TxMiddleware", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		db.SetMaxOpenConns(1)
+		c := pgctx.NewStmtCache(10)
+
+		h := pgctx.StmtCacheMiddleware(c)(pgctx.TxMiddleware(db, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+			defer cancel()
+
+			_, err := pgctx.Exec(ctx, "select 1")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		})))
+
+		// first request runs without prepare, statement is prepared after tx ends
+		mock.ExpectBegin()
+		mock.ExpectExec("select 1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+		p := mock.ExpectPrepare("select 1")
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", "/", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Eventually(t, func() bool { return c.Stats().Size == 1 }, time.Second, time.Millisecond)
+
+		// next requests use cached statement
+		for i := 0; i < 2; i++ {
+			mock.ExpectBegin()
+			p.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectCommit()
+
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("POST", "/", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, pgctx.StmtCacheStats{Size: 1, Hits: 2, Misses: 1}, c.Stats())
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		ctx, mock := newCtx(t)
+		c := pgctx.NewStmtCache(10)
+		ctx = pgctx.NewStmtCacheContext(ctx, c)
+
+		mock.ExpectPrepare("select 1").
+			WillBeClosed().
+			ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+
+		_, err := pgctx.Exec(ctx, "select 1")
+		assert.NoError(t, err)
+		assert.NoError(t, c.Close())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, 0, c.Stats().Size)
+	})
+}
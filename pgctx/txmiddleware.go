@@ -0,0 +1,146 @@
+package pgctx
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/acoshift/pgsql"
+)
+
+// TxMiddlewareOptions is the options for TxMiddleware
+type TxMiddlewareOptions struct {
+	pgsql.TxOptions
+
+	// Skip reports whether request should not run inside tx,
+	// skipped request still has db injected into its context
+	Skip func(r *http.Request) bool
+}
+
+// TxMiddleware injects db into request's context and runs handler inside tx.
+//
+// The response is buffered until tx finished,
+// tx will commit when handler responses with 1xx, 2xx or 3xx status,
+// and rollback when handler responses with 4xx, 5xx status or panic.
+// If tx failed to commit, the buffered response will be discarded
+// and 500 Internal Server Error will be sent instead.
+//
+// The handler will be called again when tx is retried,
+// the request body read by handler is buffered and replayed on retry.
+func TxMiddleware(db DB, opts *TxMiddlewareOptions) func(h http.Handler) http.Handler {
+	var option TxMiddlewareOptions
+	if opts != nil {
+		option = *opts
+	}
+
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := NewContext(r.Context(), db)
+
+			if option.Skip != nil && option.Skip(r) {
+				h.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			var body *replayBody
+			if r.Body != nil && r.Body != http.NoBody {
+				body = newReplayBody(r.Body)
+			}
+
+			var tw txResponseWriter
+			err := RunInTxOptions(ctx, &option.TxOptions, func(ctx context.Context) error {
+				// reset request and response on every attempt, tx might be retried
+				tw.reset(w.Header())
+				r := r.WithContext(ctx)
+				if body != nil {
+					body.reset()
+					r.Body = body
+				}
+				h.ServeHTTP(&tw, r)
+				if tw.status >= 400 {
+					return pgsql.ErrAbortTx
+				}
+				return nil
+			})
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			tw.writeTo(w)
+		})
+	}
+}
+
+type txResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *txResponseWriter) reset(h http.Header) {
+	w.header = h.Clone()
+	w.status = 0
+	w.body.Reset()
+}
+
+func (w *txResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *txResponseWriter) WriteHeader(statusCode int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = statusCode
+}
+
+func (w *txResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(p)
+}
+
+func (w *txResponseWriter) writeTo(rw http.ResponseWriter) {
+	h := rw.Header()
+	for k := range h {
+		if _, ok := w.header[k]; !ok {
+			delete(h, k)
+		}
+	}
+	for k, v := range w.header {
+		h[k] = v
+	}
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	rw.WriteHeader(w.status)
+	rw.Write(w.body.Bytes())
+}
+
+// replayBody records the body while reading, so it can be read again from the start
+type replayBody struct {
+	body io.ReadCloser
+	buf  bytes.Buffer
+	r    io.Reader
+}
+
+func newReplayBody(body io.ReadCloser) *replayBody {
+	return &replayBody{body: body}
+}
+
+// reset rewinds body to the start, the recorded part is replayed before the remaining body
+func (b *replayBody) reset() {
+	b.r = io.MultiReader(bytes.NewReader(b.buf.Bytes()), io.TeeReader(b.body, &b.buf))
+}
+
+func (b *replayBody) Read(p []byte) (int, error) {
+	return b.r.Read(p)
+}
+
+// Close does not close the original body, it will be closed by the server
+func (b *replayBody) Close() error {
+	return nil
+}
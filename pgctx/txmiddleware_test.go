@@ -0,0 +1,150 @@
+package pgctx_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgctx"
+)
+
+func TestTxMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Committed", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/", nil)
+		pgctx.TxMiddleware(db, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.True(t, pgctx.IsInTx(r.Context()))
+			w.Header().Set("X-Test", "1")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, "ok")
+		})).ServeHTTP(w, r)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "1", w.Header().Get("X-Test"))
+		assert.Equal(t, "ok", w.Body.String())
+	})
+
+	t.Run("Rollback on error status", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/", nil)
+		pgctx.TxMiddleware(db, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pgctx.Committed(r.Context(), func(ctx context.Context) {
+				assert.Fail(t, "should not be called")
+			})
+			http.Error(w, "bad request", http.StatusBadRequest)
+		})).ServeHTTP(w, r)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "bad request\n", w.Body.String())
+	})
+
+	t.Run("Rollback on panic", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/", nil)
+		assert.Panics(t, func() {
+			pgctx.TxMiddleware(db, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				panic("panic")
+			})).ServeHTTP(w, r)
+		})
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Commit failed", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectCommit().WillReturnError(fmt.Errorf("commit error"))
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/", nil)
+		pgctx.TxMiddleware(db, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Test", "1")
+			fmt.Fprint(w, "ok")
+		})).ServeHTTP(w, r)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, w.Header().Get("X-Test"))
+		assert.NotEqual(t, "ok", w.Body.String())
+	})
+
+	t.Run("Retry replays body", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectCommit().WillReturnError(&pq.Error{Code: "40001"})
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		var bodies []string
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/", strings.NewReader("payload"))
+		pgctx.TxMiddleware(db, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			r.Body.Close()
+			bodies = append(bodies, string(p))
+			fmt.Fprint(w, "ok")
+		})).ServeHTTP(w, r)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, []string{"payload", "payload"}, bodies)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "ok", w.Body.String())
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/", nil)
+		pgctx.TxMiddleware(db, &pgctx.TxMiddlewareOptions{
+			Skip: func(r *http.Request) bool {
+				return r.Method == http.MethodGet
+			},
+		})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.False(t, pgctx.IsInTx(r.Context()))
+			assert.NotPanics(t, func() {
+				pgctx.GetDB(r.Context())
+			})
+			fmt.Fprint(w, "ok")
+		})).ServeHTTP(w, r)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "ok", w.Body.String())
+	})
+}
@@ -1,11 +1,23 @@
 package pgstmt
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/acoshift/pgsql/internal/sqlscan"
+)
+
 // Arg marks value as argument to replace with $? when build query
 func Arg(v any) any {
 	switch v.(type) {
 	default:
 		return arg{v}
 	case arg:
+	case named:
+	case expr:
+	case *Result:
+	case builder:
+	case *group, *parenGroup:
 	case notArg:
 	case raw:
 	case _any:
@@ -19,6 +31,18 @@ type arg struct {
 	value any
 }
 
+// Named marks value as named argument,
+// the same name will be replaced with the same $? when build query.
+//
+// Use Result.Bind to bind values into named arguments.
+func Named(name string) any {
+	return named{name}
+}
+
+type named struct {
+	name string
+}
+
 // NotArg marks value as non-argument
 func NotArg(v any) any {
 	if _, ok := v.(notArg); ok {
@@ -31,14 +55,26 @@ type notArg struct {
 	value any
 }
 
-// Raw marks value as raw sql without escape
+// Raw marks value as raw sql without escape,
+// marked values and sql builders (ex. Expr, Case, *Result) are rendered as is
 func Raw(v any) any {
 	switch v := v.(type) {
 	default:
 		return raw{v}
 	case _any:
 		return Any(raw{v.value})
+	case arg:
+	case named:
+	case expr:
+	case *Result:
+	case builder:
+	case *group, *parenGroup:
+	case notArg:
+	case raw:
+	case all:
+	case defaultValue:
 	}
+	return v
 }
 
 type raw struct {
@@ -63,6 +99,52 @@ type all struct {
 	value any
 }
 
+// Expr marks sql fragment with arguments,
+// each ? in sql will be replaced with $? when build query,
+// use ?? for literal ?
+//
+// Statement using Expr returns an error if number of ? does not match number of arguments.
+func Expr(sql string, args ...any) any {
+	parts := splitExpr(sql)
+	if len(parts)-1 != len(args) {
+		return expr{err: fmt.Errorf("pgstmt: expr %q has %d placeholders but %d arguments", sql, len(parts)-1, len(args))}
+	}
+	return expr{parts: parts, args: args}
+}
+
+type expr struct {
+	parts []string // sql around placeholders
+	args  []any
+	err   error
+}
+
+// splitExpr splits sql at ? placeholders,
+// string literals, quoted identifiers, comments and dollar quoted strings are skipped
+func splitExpr(sql string) []string {
+	var parts []string
+	var b strings.Builder
+	for i := 0; i < len(sql); {
+		if j := sqlscan.Skip(sql, i); j > i {
+			b.WriteString(sql[i:j])
+			i = j
+			continue
+		}
+		switch {
+		case strings.HasPrefix(sql[i:], "??"):
+			b.WriteByte('?')
+			i += 2
+		case sql[i] == '?':
+			parts = append(parts, b.String())
+			b.Reset()
+			i++
+		default:
+			b.WriteByte(sql[i])
+			i++
+		}
+	}
+	return append(parts, b.String())
+}
+
 // Default use for insert default value
 var Default any = defaultValue{}
 
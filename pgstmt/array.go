@@ -0,0 +1,42 @@
+package pgstmt
+
+import (
+	"database/sql/driver"
+
+	"github.com/lib/pq"
+)
+
+// array wraps go slice with pq.Array,
+// pq.Array returns typed array for []bool, []float64, []float32, []int64, []int32, []string and [][]byte
+func array(v any) any {
+	switch v.(type) {
+	case driver.Valuer, arg, named, notArg, raw, expr, *Result, builder, *group, *parenGroup:
+		return v
+	}
+	return pq.Array(v)
+}
+
+// ArrayLength builds array_length(field, dimension) expression
+func ArrayLength(field any, dimension int) any {
+	return concat("array_length(", field, ", ", dimension, ")")
+}
+
+func (st *cond) ArrayContains(field, value any) {
+	st.Op(field, "@>", array(value))
+}
+
+func (st *cond) ArrayContainedBy(field, value any) {
+	st.Op(field, "<@", array(value))
+}
+
+func (st *cond) ArrayOverlap(field, value any) {
+	st.Op(field, "&&", array(value))
+}
+
+func (st *cond) EqAny(field, value any) {
+	st.Op(field, "=", Any(array(value)))
+}
+
+func (st *cond) NeAll(field, value any) {
+	st.Op(field, "<>", All(array(value)))
+}
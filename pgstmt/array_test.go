@@ -0,0 +1,54 @@
+package pgstmt_test
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestArray(t *testing.T) {
+	t.Parallel()
+
+	q, args := pgstmt.Select(func(b pgstmt.SelectStatement) {
+		b.Columns("*", pgstmt.ArrayLength("tags", 1))
+		b.From("posts")
+		b.Where(func(b pgstmt.Cond) {
+			b.ArrayContains("tags", []string{"a", "b"})
+			b.ArrayContainedBy("scores", []int64{1, 2})
+			b.ArrayOverlap("flags", []bool{true})
+			b.EqAny("id", []int64{1, 2, 3})
+			b.NeAll("ratio", []float64{0.5})
+			b.EqAny("hash", [][]byte{{1}})
+			b.EqAny("x", pq.Array([]int32{1}))
+			b.EqAny("y", pgstmt.Raw("array[1, 2]"))
+			b.Gt(pgstmt.ArrayLength("tags", 1), 2)
+		})
+	}).SQL()
+
+	assert.Equal(t, stripSpace(`
+		select *, array_length(tags, 1)
+		from posts
+		where (tags @> $1
+			and scores <@ $2
+			and flags && $3
+			and id = any($4)
+			and ratio <> all($5)
+			and hash = any($6)
+			and x = any($7)
+			and y = any(array[1, 2])
+			and array_length(tags, 1) > $8)
+	`), q)
+	assert.EqualValues(t, []any{
+		&pq.StringArray{"a", "b"},
+		&pq.Int64Array{1, 2},
+		&pq.BoolArray{true},
+		&pq.Int64Array{1, 2, 3},
+		&pq.Float64Array{0.5},
+		&pq.ByteaArray{{1}},
+		&pq.Int32Array{1},
+		2,
+	}, args)
+}
@@ -1,12 +1,15 @@
 package pgstmt
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/lib/pq"
+
+	"github.com/acoshift/pgsql/internal/sqlscan"
 )
 
 type buffer struct {
@@ -30,6 +33,12 @@ func (b *buffer) popFront() any {
 	return p
 }
 
+// clone returns copy of buffer, items are shared
+func (b buffer) clone() buffer {
+	b.q = append([]any(nil), b.q...)
+	return b
+}
+
 func (b *buffer) empty() bool {
 	return len(b.q) == 0
 }
@@ -42,55 +51,334 @@ type builder interface {
 	build() []any
 }
 
-func build(b *buffer) (string, []any) {
-	var args []any
-	var i int
+func build(b *buffer) (string, []any, error) {
+	var r renderer
+	query := r.render(b.q, " ")
+	return query, r.args, r.error()
+}
+
+// Format returns new result with indented, multi-line query,
+// one clause per line and nested subqueries indented.
+//
+// The arguments of formatted result are identical to r.
+func Format(r *Result) *Result {
+	if r.b == nil {
+		return r
+	}
+
+	// arguments are the same as r, might already bind named arguments
+	x := renderer{pretty: true, strict: r.strict, scope: r.scope, scopeArg: len(r.args)}
+	query := x.renderResult(r.b)
+	f := *r
+	f.query = query
+	f.pretty = true
+	return &f
+}
+
+type renderer struct {
+	args   []any
+	named  map[string]int
+	pretty bool
+	strict bool
+	ddl    bool
+	err    error
+
+	scope    *Scope
+	required []string // tables required to be scoped
+	scopeErr error    // ErrScopeRequired, reported only if there is no other error
+	scopeArg int      // argument number of scope value
+	scoped   bool     // scope value is used
+}
+
+// buildError is the error found while building statement,
+// reported when statement is rendered
+type buildError struct {
+	err error
+}
+
+func (r *renderer) setErr(err error) {
+	if errors.Is(err, ErrScopeRequired) {
+		if r.scopeErr == nil {
+			r.scopeErr = err
+		}
+		return
+	}
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *renderer) error() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.scopeErr
+}
+
+func (r *renderer) renderResult(b *buffer) string {
+	if r.pretty {
+		return r.renderClauses(b.q)
+	}
+	return r.render(b.q, " ")
+}
+
+func (r *renderer) arg(v any) string {
+	if r.ddl {
+		// ddl can not have parameters
+		s, err := ddlLiteral(v)
+		if err != nil {
+			r.setErr(err)
+		}
+		return s
+	}
 
-	var f func(p []any, sep string) string
-	f = func(p []any, sep string) string {
-		var q []string
-		for _, x := range p {
-			switch x := x.(type) {
+	if x, ok := v.(named); ok {
+		if i, ok := r.named[x.name]; ok {
+			return "$" + strconv.Itoa(i)
+		}
+		if r.named == nil {
+			r.named = map[string]int{}
+		}
+		r.named[x.name] = len(r.args) + 1
+	}
+
+	r.args = append(r.args, v)
+	return "$" + strconv.Itoa(len(r.args))
+}
+
+func (r *renderer) ident(x ident) string {
+	if r.ddl {
+		s, err := x.quote()
+		if err != nil {
+			// not an identifier chain, quote as a single identifier
+			return pq.QuoteIdentifier(x.value)
+		}
+		return s
+	}
+	if !r.strict {
+		return x.value
+	}
+	s, err := x.quote()
+	if err != nil {
+		r.setErr(err)
+	}
+	return s
+}
+
+func (r *renderer) expr(x expr) string {
+	if x.err != nil {
+		r.setErr(x.err)
+		return ""
+	}
+	var b strings.Builder
+	for i, p := range x.parts {
+		if i > 0 {
+			b.WriteString(r.render([]any{Arg(x.args[i-1])}, ""))
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+// result renders built result as part of query, renumbers result's $? into query's arguments
+func (r *renderer) result(x *Result) string {
+	if x.scope != nil && len(r.required) > 0 {
+		// check x's tables not covered by x's scope
+		x = x.rescope(x.scope, r.required)
+	}
+	query := x.query
+	err := x.err
+	scopeArg := 0
+	if (r.scope != nil || len(r.required) > 0) && x.scope == nil && x.b != nil {
+		// render x with scope, x's arguments keep their numbers (and bound values),
+		// scope value is numbered after x's arguments
+		sub := renderer{pretty: x.pretty || r.pretty, strict: x.strict, scope: r.scope, required: r.required, scopeArg: len(x.args) + 1}
+		query = sub.renderResult(x.b)
+		err = sub.error()
+		if sub.scoped {
+			scopeArg = sub.scopeArg
+		}
+	} else if r.pretty && !x.pretty && x.b != nil {
+		// render x indented, x's arguments (include scope value) keep their numbers
+		sub := renderer{pretty: true, strict: x.strict, scope: x.scope, scopeArg: len(x.args)}
+		query = sub.renderResult(x.b)
+	}
+	if err != nil {
+		r.setErr(err)
+	}
+
+	var b strings.Builder
+	pos := map[int]string{}
+
+	for i := 0; i < len(query); {
+		if j := sqlscan.Skip(query, i); j > i {
+			b.WriteString(query[i:j])
+			i = j
+			continue
+		}
+		n, j, ok := sqlscan.Placeholder(query, i)
+		if ok && n == scopeArg {
+			b.WriteString(r.scopeValue())
+			i = j
+			continue
+		}
+		if ok && n >= 1 && n <= len(x.args) {
+			p, ok := pos[n]
+			if !ok {
+				p = r.arg(x.args[n-1])
+				pos[n] = p
+			}
+			b.WriteString(p)
+			i = j
+			continue
+		}
+		b.WriteByte(query[i])
+		i++
+	}
+	return b.String()
+}
+
+func (r *renderer) render(p []any, sep string) string {
+	var q []string
+	for _, x := range p {
+		switch x := x.(type) {
+		default:
+			q = append(q, convertToString(x, false))
+		case *buffer:
+			if r.pretty {
+				q = append(q, r.renderClauses(x.q))
+			} else {
+				q = append(q, r.render(x.q, " "))
+			}
+		case builder:
+			q = append(q, r.render(x.build(), " "))
+		case arg:
+			q = append(q, r.arg(x.value))
+		case named:
+			q = append(q, r.arg(x))
+		case expr:
+			q = append(q, r.expr(x))
+		case *Result:
+			if r.pretty {
+				q = append(q, "(\n"+indent(r.result(x))+"\n)")
+			} else {
+				q = append(q, "("+r.result(x)+")")
+			}
+		case ident:
+			q = append(q, r.ident(x))
+		case scopeValue:
+			q = append(q, r.scopeValue())
+		case *scopeCond:
+			if s := r.scopeCond(x); s != "" {
+				q = append(q, s)
+			}
+		case *scopeInsert:
+			q = append(q, r.scopeInsert(x))
+		case *scopeTable:
+			q = append(q, r.scopeTable(x))
+		case *scopeDeny:
+			r.scopeDeny(x)
+		case buildError:
+			r.setErr(x.err)
+		case ddl:
+			prev := r.ddl
+			r.ddl = true
+			q = append(q, r.render([]any{x.b}, sep))
+			r.ddl = prev
+		case _any:
+			switch x := x.value.(type) {
+			case raw, notArg:
+				q = append(q, fmt.Sprintf("any(%s)", convertToString(x, false)))
 			default:
-				q = append(q, convertToString(x, false))
-			case builder:
-				q = append(q, f(x.build(), " "))
-			case arg:
-				i++
-				q = append(q, "$"+strconv.Itoa(i))
-				args = append(args, x.value)
-			case _any:
-				switch x := x.value.(type) {
-				case raw, notArg:
-					q = append(q, fmt.Sprintf("any(%s)", convertToString(x, false)))
-				default:
-					i++
-					q = append(q, fmt.Sprintf("any($%d)", i))
-					args = append(args, x)
-				}
-			case all:
-				switch x := x.value.(type) {
-				case raw, notArg:
-					q = append(q, fmt.Sprintf("all(%s)", convertToString(x, false)))
-				default:
-					i++
-					q = append(q, fmt.Sprintf("all($%d)", i))
-					args = append(args, x)
-				}
-			case *group:
-				if !x.empty() {
-					q = append(q, f(x.q, x.getSep()))
-				}
-			case *parenGroup:
-				if !x.empty() {
-					q = append(q, x.prefix+"("+f(x.q, x.getSep())+")")
+				q = append(q, fmt.Sprintf("any(%s)", r.arg(x)))
+			}
+		case all:
+			switch x := x.value.(type) {
+			case raw, notArg:
+				q = append(q, fmt.Sprintf("all(%s)", convertToString(x, false)))
+			default:
+				q = append(q, fmt.Sprintf("all(%s)", r.arg(x)))
+			}
+		case *group:
+			if !x.empty() {
+				q = append(q, r.render(x.q, x.getSep()))
+			}
+		case *parenGroup:
+			if !x.empty() {
+				if r.pretty && x.hasStatement() {
+					s := r.render(x.q, x.getSep())
+					q = append(q, x.prefix+"(\n"+indent(s)+"\n)")
+				} else {
+					q = append(q, x.prefix+"("+r.render(x.q, x.getSep())+")")
 				}
 			}
 		}
-		return strings.Join(q, sep)
 	}
-	query := f(b.q, " ")
-	return query, args
+	return strings.Join(q, sep)
+}
+
+// renderClauses renders statement buffer with one clause per line
+func (r *renderer) renderClauses(p []any) string {
+	var lines []string
+	var line []any
+	flush := func() {
+		if len(line) > 0 {
+			if s := r.render(line, " "); s != "" {
+				lines = append(lines, s)
+			}
+			line = nil
+		}
+	}
+	for _, x := range p {
+		if isClause(x) {
+			flush()
+		}
+		line = append(line, x)
+	}
+	flush()
+	return strings.Join(lines, "\n")
+}
+
+var clauseKeywords = map[string]bool{
+	"with":             true,
+	"select":           true,
+	"from":             true,
+	"where":            true,
+	"group by":         true,
+	"having":           true,
+	"order by":         true,
+	"limit":            true,
+	"offset":           true,
+	"returning":        true,
+	"union":            true,
+	"union all":        true,
+	"values":           true,
+	"set":              true,
+	"on conflict":      true,
+	"default values":   true,
+	"where current of": true,
+	"overriding":       true,
+	"do":               true,
+	"do nothing":       true,
+}
+
+func isClause(x any) bool {
+	switch x := x.(type) {
+	case string:
+		return clauseKeywords[x]
+	case *join, *fetch:
+		return true
+	case *scopeCond:
+		return x.keyword == "where"
+	case *buffer:
+		// nested clause, ex. on conflict
+		return !x.empty() && isClause(x.q[0])
+	}
+	return false
+}
+
+func indent(s string) string {
+	return "\t" + strings.ReplaceAll(s, "\n", "\n\t")
 }
 
 func convertToString(x any, quoteStr bool) string {
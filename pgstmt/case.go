@@ -0,0 +1,111 @@
+package pgstmt
+
+import "fmt"
+
+// Case builds case expression
+func Case(f func(b CaseBuilder)) any {
+	var x caseExpr
+	f(&x)
+	return &x
+}
+
+// CaseBuilder is the case expression builder
+type CaseBuilder interface {
+	// Field sets case operand for simple case expression,
+	// case field when value then result end
+	Field(field any)
+
+	// When adds searched when clause
+	When(f func(b Cond)) CaseResult
+
+	// WhenValue adds simple when clause, use with Field
+	WhenValue(value any) CaseResult
+	WhenValueRaw(rawValue any) CaseResult
+
+	Else(value any)
+	ElseRaw(rawValue any)
+}
+
+type CaseResult interface {
+	Then(value any)
+	ThenRaw(rawValue any)
+}
+
+type caseExpr struct {
+	field any
+	whens []*caseWhen
+	els   any
+}
+
+func (b *caseExpr) Field(field any) {
+	b.field = field
+}
+
+func (b *caseExpr) When(f func(b Cond)) CaseResult {
+	var x cond
+	f(&x)
+
+	w := caseWhen{when: &x}
+	b.whens = append(b.whens, &w)
+	return &w
+}
+
+func (b *caseExpr) WhenValue(value any) CaseResult {
+	w := caseWhen{when: Arg(value)}
+	b.whens = append(b.whens, &w)
+	return &w
+}
+
+func (b *caseExpr) WhenValueRaw(rawValue any) CaseResult {
+	w := caseWhen{when: Raw(rawValue)}
+	b.whens = append(b.whens, &w)
+	return &w
+}
+
+func (b *caseExpr) Else(value any) {
+	b.els = Arg(value)
+}
+
+func (b *caseExpr) ElseRaw(rawValue any) {
+	b.els = Raw(rawValue)
+}
+
+func (b *caseExpr) build() []any {
+	var x group
+	x.sep = " "
+	x.push("case")
+	if len(b.whens) == 0 {
+		x.push(buildError{fmt.Errorf("pgstmt: case without when")})
+	}
+	if b.field != nil {
+		x.push(b.field)
+	}
+	for _, w := range b.whens {
+		x.push(w)
+	}
+	if b.els != nil {
+		x.push("else", b.els)
+	}
+	x.push("end")
+	return []any{&x}
+}
+
+type caseWhen struct {
+	when any
+	then any
+}
+
+func (w *caseWhen) Then(value any) {
+	w.then = Arg(value)
+}
+
+func (w *caseWhen) ThenRaw(rawValue any) {
+	w.then = Raw(rawValue)
+}
+
+func (w *caseWhen) build() []any {
+	if w.then == nil {
+		return []any{buildError{fmt.Errorf("pgstmt: case when without then")}, "when", w.when}
+	}
+	return []any{"when", w.when, "then", w.then}
+}
@@ -0,0 +1,139 @@
+package pgstmt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestCase(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		result *pgstmt.Result
+		query  string
+		args   []any
+	}{
+		{
+			"searched",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("id", pgstmt.Case(func(b pgstmt.CaseBuilder) {
+					b.When(func(b pgstmt.Cond) {
+						b.Gt("score", 80)
+					}).Then("A")
+					b.When(func(b pgstmt.Cond) {
+						b.Gt("score", 50)
+						b.IsNotNull("submitted_at")
+					}).Then("B")
+					b.Else("C")
+				}))
+				b.From("students")
+				b.OrderByExpr(pgstmt.Case(func(b pgstmt.CaseBuilder) {
+					b.When(func(b pgstmt.Cond) {
+						b.Eq("status", "pending")
+					}).ThenRaw(0)
+					b.ElseRaw(1)
+				}))
+				b.OrderBy("id")
+			}),
+			`select id, case when (score > $1) then $2 when (score > $3 and submitted_at is not null) then $4 else $5 end
+			from students
+			order by case when (status = $6) then 0 else 1 end, id`,
+			[]any{80, "A", 50, "B", "C", "pending"},
+		},
+		{
+			"simple",
+			pgstmt.Update(func(b pgstmt.UpdateStatement) {
+				b.Table("users")
+				b.Set("role").To(pgstmt.Case(func(b pgstmt.CaseBuilder) {
+					b.Field("id")
+					b.WhenValue(1).Then("admin")
+					b.WhenValueRaw(2).ThenRaw("role")
+					b.Else(nil)
+				}))
+				b.Where(func(b pgstmt.Cond) {
+					b.Eq(pgstmt.Case(func(b pgstmt.CaseBuilder) {
+						b.Field("type")
+						b.WhenValue("a").Then(true)
+						b.ElseRaw(false)
+					}), true)
+				})
+			}),
+			`update users
+			set role = case id when $1 then $2 when 2 then role else $3 end
+			where (case type when $4 then $5 else false end = $6)`,
+			[]any{1, "admin", nil, "a", true, true},
+		},
+	}
+
+	for _, tC := range cases {
+		t.Run(tC.name, func(t *testing.T) {
+			q, args := tC.result.SQL()
+			assert.Equal(t, stripSpace(tC.query), q)
+			assert.EqualValues(t, tC.args, args)
+		})
+	}
+}
+
+func TestCase_Raw(t *testing.T) {
+	t.Parallel()
+
+	q, args := pgstmt.Select(func(b pgstmt.SelectStatement) {
+		b.Columns(pgstmt.Case(func(b pgstmt.CaseBuilder) {
+			b.Field("type")
+			b.WhenValueRaw(pgstmt.Expr("upper(?)", "a")).ThenRaw(pgstmt.Case(func(b pgstmt.CaseBuilder) {
+				b.When(func(b pgstmt.Cond) {
+					b.Gt("score", 10)
+				}).Then("high")
+				b.ElseRaw(pgstmt.Expr("lower(?)", "LOW"))
+			}))
+			b.ElseRaw(pgstmt.Expr("coalesce(name, ?)", "x"))
+		}))
+		b.From("users")
+		b.Where(func(b pgstmt.Cond) {
+			b.EqRaw("status", pgstmt.Case(func(b pgstmt.CaseBuilder) {
+				b.When(func(b pgstmt.Cond) {
+					b.IsNull("deleted_at")
+				}).Then("active")
+				b.Else("deleted")
+			}))
+		})
+	}).SQL()
+	assert.Equal(t, stripSpace(`
+		select case type when upper($1) then case when (score > $2) then $3 else lower($4) end else coalesce(name, $5) end
+		from users
+		where (status = case when (deleted_at is null) then $6 else $7 end)
+	`), q)
+	assert.EqualValues(t, []any{"a", 10, "high", "LOW", "x", "active", "deleted"}, args)
+}
+
+func TestCase_Invalid(t *testing.T) {
+	t.Parallel()
+
+	build := func(f func(b pgstmt.CaseBuilder)) *pgstmt.Result {
+		return pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns(pgstmt.Case(f))
+		})
+	}
+
+	assert.Error(t, build(func(b pgstmt.CaseBuilder) {
+		b.When(func(b pgstmt.Cond) {
+			b.Eq("a", 1)
+		})
+	}).Err())
+	assert.Error(t, build(func(b pgstmt.CaseBuilder) {
+		b.Field("a")
+		b.WhenValue(1)
+		b.Else(2)
+	}).Err())
+	assert.Error(t, build(func(b pgstmt.CaseBuilder) {}).Err())
+	assert.Error(t, build(func(b pgstmt.CaseBuilder) {
+		b.Else(1)
+	}).Err())
+	assert.NoError(t, build(func(b pgstmt.CaseBuilder) {
+		b.WhenValue(1).Then(nil)
+	}).Err())
+}
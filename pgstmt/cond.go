@@ -20,18 +20,106 @@ type Cond interface {
 	LikeRaw(field, rawValue any)
 	ILike(field, value any)
 	ILikeRaw(field, rawValue any)
+	NotLike(field, value any)
+	NotLikeRaw(field, rawValue any)
+	NotILike(field, value any)
+	NotILikeRaw(field, rawValue any)
+	SimilarTo(field, value any)
+	SimilarToRaw(field, rawValue any)
+	NotSimilarTo(field, value any)
+	NotSimilarToRaw(field, rawValue any)
+	Regex(field, value any)
+	RegexRaw(field, rawValue any)
+	IRegex(field, value any)
+	IRegexRaw(field, rawValue any)
+	NotRegex(field, value any)
+	NotRegexRaw(field, rawValue any)
+	NotIRegex(field, value any)
+	NotIRegexRaw(field, rawValue any)
+	IsDistinctFrom(field, value any)
+	IsDistinctFromRaw(field, rawValue any)
+	IsNotDistinctFrom(field, value any)
+	IsNotDistinctFromRaw(field, rawValue any)
+	Between(field, from, to any)
+	BetweenRaw(field, rawFrom, rawTo any)
+	NotBetween(field, from, to any)
+	NotBetweenRaw(field, rawFrom, rawTo any)
+	BetweenSymmetric(field, from, to any)
+	BetweenSymmetricRaw(field, rawFrom, rawTo any)
 	In(field any, value ...any)
 	InRaw(field any, value ...any)
 	InSelect(field any, f func(b SelectStatement))
+	InResult(field any, r *Result)
 	NotIn(field any, value ...any)
 	NotInRaw(field any, value ...any)
 	IsNull(field any)
 	IsNotNull(field any)
+	IsTrue(field any)
+	IsNotTrue(field any)
+	IsFalse(field any)
+	IsNotFalse(field any)
+	IsUnknown(field any)
+	IsNotUnknown(field any)
+	Exists(f func(b SelectStatement))
+	NotExists(f func(b SelectStatement))
+
+	// JSONContains renders field @> value::jsonb, value will be marshaled into json
+	JSONContains(field, value any)
+	// JSONContainedBy renders field <@ value::jsonb, value will be marshaled into json
+	JSONContainedBy(field, value any)
+	// JSONHasKey renders field ? key
+	JSONHasKey(field any, key string)
+	// JSONHasAnyKeys renders field ?| keys
+	JSONHasAnyKeys(field any, keys ...string)
+	// JSONHasAllKeys renders field ?& keys
+	JSONHasAllKeys(field any, keys ...string)
+	// JSONPathExists renders field @? path::jsonpath
+	JSONPathExists(field any, path string)
+	// JSONPathMatch renders field @@ path::jsonpath
+	JSONPathMatch(field any, path string)
+
+	// ArrayContains renders field @> value, slice value will be wrapped with pq.Array
+	ArrayContains(field, value any)
+	// ArrayContainedBy renders field <@ value, slice value will be wrapped with pq.Array
+	ArrayContainedBy(field, value any)
+	// ArrayOverlap renders field && value, slice value will be wrapped with pq.Array
+	ArrayOverlap(field, value any)
+	// EqAny renders field = any(value), slice value will be wrapped with pq.Array
+	EqAny(field, value any)
+	// NeAll renders field <> all(value), slice value will be wrapped with pq.Array
+	NeAll(field, value any)
+
+	// Match renders document @@ query for full-text search
+	Match(document any, query TextQuery)
+
+	// EqIfNotZero calls Eq if value is not zero, zero value is the same as pgsql.Null
+	EqIfNotZero(field, value any)
+	// NeIfNotZero calls Ne if value is not zero
+	NeIfNotZero(field, value any)
+	// LtIfNotZero calls Lt if value is not zero
+	LtIfNotZero(field, value any)
+	// LeIfNotZero calls Le if value is not zero
+	LeIfNotZero(field, value any)
+	// GtIfNotZero calls Gt if value is not zero
+	GtIfNotZero(field, value any)
+	// GeIfNotZero calls Ge if value is not zero
+	GeIfNotZero(field, value any)
+	// LikeIfNotZero calls Like if value is not zero
+	LikeIfNotZero(field, value any)
+	// ILikeIfNotZero calls ILike if value is not zero
+	ILikeIfNotZero(field, value any)
+	// InIfNotEmpty calls In if value is not empty
+	InIfNotEmpty(field any, value ...any)
+	// NotInIfNotEmpty calls NotIn if value is not empty
+	NotInIfNotEmpty(field any, value ...any)
+	// When calls f if ok is true
+	When(ok bool, f func(b Cond))
 
 	Field(field any) CondOp
 	Value(value any) CondOp
 
 	Raw(sql string)
+	Expr(sql string, args ...any)
 	Not(f func(b Cond))
 	And(f func(b Cond))
 	Or(f func(b Cond))
@@ -54,10 +142,29 @@ type CondOp interface {
 	Ge() CondValue
 	Like() CondValue
 	ILike() CondValue
+	NotLike() CondValue
+	NotILike() CondValue
+	SimilarTo() CondValue
+	NotSimilarTo() CondValue
+	Regex() CondValue
+	IRegex() CondValue
+	NotRegex() CondValue
+	NotIRegex() CondValue
+	IsDistinctFrom() CondValue
+	IsNotDistinctFrom() CondValue
+	Between() CondRange
+	NotBetween() CondRange
+	BetweenSymmetric() CondRange
 	In() CondValues
 	NotIn() CondValues
 	IsNull()
 	IsNotNull()
+	IsTrue()
+	IsNotTrue()
+	IsFalse()
+	IsNotFalse()
+	IsUnknown()
+	IsNotUnknown()
 }
 
 type CondValue interface {
@@ -66,11 +173,18 @@ type CondValue interface {
 	Field(field any)
 }
 
+type CondRange interface {
+	Value(from, to any)
+	Raw(rawFrom, rawTo any)
+	Field(from, to any)
+}
+
 type CondValues interface {
 	Value(values ...any)
 	Raw(rawValues ...any)
 	Field(field any)
 	Select(f func(b SelectStatement))
+	Result(r *Result)
 }
 
 type cond struct {
@@ -79,6 +193,49 @@ type cond struct {
 	nested bool
 }
 
+func (st cond) clone() cond {
+	st.ops.group = st.ops.group.clone()
+	st.chain = st.chain.clone()
+	return st
+}
+
+// op pushes value without mark as argument
+func (st *cond) op(field any, op string, value any) {
+	var x group
+	x.sep = " "
+	x.push(field, op, value)
+	st.ops.push(&x)
+}
+
+// and returns new condition of st and (field op value),
+// st is grouped to keep its or conditions
+func (st *cond) and(field any, op string, value any) cond {
+	var x group
+	x.sep = " "
+	x.push(field, op, value)
+	return st.andOp(&x)
+}
+
+// andOp returns new condition of st and op,
+// st is grouped to keep its or conditions
+func (st *cond) andOp(op any) cond {
+	c := st.clone()
+	if c.empty() || (c.chain.empty() && c.ops.sep != " or ") {
+		c.ops.push(op)
+		return c
+	}
+
+	var x cond
+	x.nested = c.nested
+	if c.chain.empty() {
+		x.ops.push(&c) // ops are rendered in parentheses
+	} else {
+		x.ops.push(paren(&c))
+	}
+	x.ops.push(op)
+	return x
+}
+
 func (st *cond) Op(field any, op string, value any) {
 	var x group
 	x.sep = " "
@@ -157,6 +314,117 @@ func (st *cond) ILikeRaw(field, rawValue any) {
 	st.OpRaw(field, "ilike", rawValue)
 }
 
+func (st *cond) NotLike(field, value any) {
+	st.Op(field, "not like", value)
+}
+
+func (st *cond) NotLikeRaw(field, rawValue any) {
+	st.OpRaw(field, "not like", rawValue)
+}
+
+func (st *cond) NotILike(field, value any) {
+	st.Op(field, "not ilike", value)
+}
+
+func (st *cond) NotILikeRaw(field, rawValue any) {
+	st.OpRaw(field, "not ilike", rawValue)
+}
+
+func (st *cond) SimilarTo(field, value any) {
+	st.Op(field, "similar to", value)
+}
+
+func (st *cond) SimilarToRaw(field, rawValue any) {
+	st.OpRaw(field, "similar to", rawValue)
+}
+
+func (st *cond) NotSimilarTo(field, value any) {
+	st.Op(field, "not similar to", value)
+}
+
+func (st *cond) NotSimilarToRaw(field, rawValue any) {
+	st.OpRaw(field, "not similar to", rawValue)
+}
+
+func (st *cond) Regex(field, value any) {
+	st.Op(field, "~", value)
+}
+
+func (st *cond) RegexRaw(field, rawValue any) {
+	st.OpRaw(field, "~", rawValue)
+}
+
+func (st *cond) IRegex(field, value any) {
+	st.Op(field, "~*", value)
+}
+
+func (st *cond) IRegexRaw(field, rawValue any) {
+	st.OpRaw(field, "~*", rawValue)
+}
+
+func (st *cond) NotRegex(field, value any) {
+	st.Op(field, "!~", value)
+}
+
+func (st *cond) NotRegexRaw(field, rawValue any) {
+	st.OpRaw(field, "!~", rawValue)
+}
+
+func (st *cond) NotIRegex(field, value any) {
+	st.Op(field, "!~*", value)
+}
+
+func (st *cond) NotIRegexRaw(field, rawValue any) {
+	st.OpRaw(field, "!~*", rawValue)
+}
+
+func (st *cond) IsDistinctFrom(field, value any) {
+	st.Op(field, "is distinct from", value)
+}
+
+func (st *cond) IsDistinctFromRaw(field, rawValue any) {
+	st.OpRaw(field, "is distinct from", rawValue)
+}
+
+func (st *cond) IsNotDistinctFrom(field, value any) {
+	st.Op(field, "is not distinct from", value)
+}
+
+func (st *cond) IsNotDistinctFromRaw(field, rawValue any) {
+	st.OpRaw(field, "is not distinct from", rawValue)
+}
+
+func (st *cond) between(field any, op string, from, to any) {
+	var x group
+	x.sep = " "
+	x.push(field, op, from, "and", to)
+	st.ops.push(&x)
+}
+
+func (st *cond) Between(field, from, to any) {
+	st.between(field, "between", Arg(from), Arg(to))
+}
+
+func (st *cond) BetweenRaw(field, rawFrom, rawTo any) {
+	st.between(field, "between", Raw(rawFrom), Raw(rawTo))
+}
+
+func (st *cond) NotBetween(field, from, to any) {
+	st.between(field, "not between", Arg(from), Arg(to))
+}
+
+func (st *cond) NotBetweenRaw(field, rawFrom, rawTo any) {
+	st.between(field, "not between", Raw(rawFrom), Raw(rawTo))
+}
+
+func (st *cond) BetweenSymmetric(field, from, to any) {
+	st.between(field, "between symmetric", Arg(from), Arg(to))
+}
+
+func (st *cond) BetweenSymmetricRaw(field, rawFrom, rawTo any) {
+	st.between(field, "between symmetric", Raw(rawFrom), Raw(rawTo))
+}
+
 func (st *cond) In(field any, value ...any) {
 	var p group
 	for _, v := range value {
@@ -189,6 +457,13 @@ func (st *cond) InSelect(field any, f func(b SelectStatement)) {
 	st.ops.push(&p)
 }
 
+func (st *cond) InResult(field any, r *Result) {
+	var p group
+	p.sep = " "
+	p.push(field, "in", r)
+	st.ops.push(&p)
+}
+
 func (st *cond) NotIn(field any, value ...any) {
 	var p group
 	for _, v := range value {
@@ -225,6 +500,51 @@ func (st *cond) IsNotNull(field any) {
 	st.ops.push(&x)
 }
 
+func (st *cond) is(field any, s string) {
+	var x group
+	x.sep = " "
+	x.push(field, s)
+	st.ops.push(&x)
+}
+
+func (st *cond) IsTrue(field any) {
+	st.is(field, "is true")
+}
+
+func (st *cond) IsNotTrue(field any) {
+	st.is(field, "is not true")
+}
+
+func (st *cond) IsFalse(field any) {
+	st.is(field, "is false")
+}
+
+func (st *cond) IsNotFalse(field any) {
+	st.is(field, "is not false")
+}
+
+func (st *cond) IsUnknown(field any) {
+	st.is(field, "is unknown")
+}
+
+func (st *cond) IsNotUnknown(field any) {
+	st.is(field, "is not unknown")
+}
+
+func (st *cond) Exists(f func(b SelectStatement)) {
+	var x selectStmt
+	f(&x)
+
+	st.ops.push(withGroup(" ", "exists", paren(x.make())))
+}
+
+func (st *cond) NotExists(f func(b SelectStatement)) {
+	var x selectStmt
+	f(&x)
+
+	st.ops.push(withGroup(" ", "not exists", paren(x.make())))
+}
+
 func (st *cond) Field(field any) CondOp {
 	var x condOp
 	x.field = field
@@ -243,6 +563,10 @@ func (st *cond) Raw(sql string) {
 	st.ops.push(sql)
 }
 
+func (st *cond) Expr(sql string, args ...any) {
+	st.ops.push(Expr(sql, args...))
+}
+
 func (st *cond) Not(b func(b Cond)) {
 	var x cond
 	x.ops.sep = " and "
@@ -292,16 +616,17 @@ func (st *cond) build() []any {
 	}
 
 	if st.ops.empty() {
-		st.chain.popFront()
+		// skip first and/or, do not modify chain to allow build multiple times
+		chain := st.chain.q[1:]
 
-		if len(st.chain.q) > 1 {
+		if len(chain) > 1 {
 			var b parenGroup
 			b.sep = " "
-			b.push(st.chain.q...)
+			b.push(chain...)
 			return []any{&b}
 		}
 
-		return st.chain.q
+		return chain
 	}
 
 	if st.ops.sep == "" {
@@ -339,6 +664,7 @@ type condOp struct {
 	op     string
 	value  *condValue
 	values *condValues
+	rng    *condRange
 }
 
 func (op *condOp) build() []any {
@@ -351,6 +677,8 @@ func (op *condOp) build() []any {
 		x.push(op.value.value)
 	} else if op.values != nil {
 		x.push(&op.values.b)
+	} else if op.rng != nil {
+		x.push(op.rng.from, "and", op.rng.to)
 	}
 
 	b.push(&x)
@@ -402,6 +730,64 @@ func (op *condOp) ILike() CondValue {
 	return op.Op("ilike")
 }
 
+func (op *condOp) NotLike() CondValue {
+	return op.Op("not like")
+}
+
+func (op *condOp) NotILike() CondValue {
+	return op.Op("not ilike")
+}
+
+func (op *condOp) SimilarTo() CondValue {
+	return op.Op("similar to")
+}
+
+func (op *condOp) NotSimilarTo() CondValue {
+	return op.Op("not similar to")
+}
+
+func (op *condOp) Regex() CondValue {
+	return op.Op("~")
+}
+
+func (op *condOp) IRegex() CondValue {
+	return op.Op("~*")
+}
+
+func (op *condOp) NotRegex() CondValue {
+	return op.Op("!~")
+}
+
+func (op *condOp) NotIRegex() CondValue {
+	return op.Op("!~*")
+}
+
+func (op *condOp) IsDistinctFrom() CondValue {
+	return op.Op("is distinct from")
+}
+
+func (op *condOp) IsNotDistinctFrom() CondValue {
+	return op.Op("is not distinct from")
+}
+
+func (op *condOp) opRange(s string) CondRange {
+	op.op = s
+	op.rng = &condRange{}
+	return op.rng
+}
+
+func (op *condOp) Between() CondRange {
+	return op.opRange("between")
+}
+
+func (op *condOp) NotBetween() CondRange {
+	return op.opRange("not between")
+}
+
+func (op *condOp) BetweenSymmetric() CondRange {
+	return op.opRange("between symmetric")
+}
+
 func (op *condOp) In() CondValues {
 	return op.OpValues("in")
 }
@@ -418,6 +804,30 @@ func (op *condOp) IsNotNull() {
 	op.op = "is not null"
 }
 
+func (op *condOp) IsTrue() {
+	op.op = "is true"
+}
+
+func (op *condOp) IsNotTrue() {
+	op.op = "is not true"
+}
+
+func (op *condOp) IsFalse() {
+	op.op = "is false"
+}
+
+func (op *condOp) IsNotFalse() {
+	op.op = "is not false"
+}
+
+func (op *condOp) IsUnknown() {
+	op.op = "is unknown"
+}
+
+func (op *condOp) IsNotUnknown() {
+	op.op = "is not unknown"
+}
+
 type condValue struct {
 	value any
 }
@@ -440,6 +850,26 @@ func (v *condValue) Field(field any) {
 	v.value = Raw(field)
 }
 
+type condRange struct {
+	from any
+	to   any
+}
+
+func (v *condRange) Value(from, to any) {
+	v.from = Arg(from)
+	v.to = Arg(to)
+}
+
+func (v *condRange) Raw(rawFrom, rawTo any) {
+	v.from = Raw(rawFrom)
+	v.to = Raw(rawTo)
+}
+
+func (v *condRange) Field(from, to any) {
+	v.from = Raw(from)
+	v.to = Raw(to)
+}
+
 type condValues struct {
 	b buffer
 }
@@ -468,3 +898,7 @@ func (v *condValues) Select(f func(b SelectStatement)) {
 
 	v.b.push(paren(x.make()))
 }
+
+func (v *condValues) Result(r *Result) {
+	v.b.push(r)
+}
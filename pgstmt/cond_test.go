@@ -0,0 +1,130 @@
+package pgstmt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestCond(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		cond  func(b pgstmt.Cond)
+		query string
+		args  []any
+	}{
+		{
+			"between",
+			func(b pgstmt.Cond) {
+				b.Between("age", 18, 60)
+				b.NotBetweenRaw("created_at", "now() - interval '1 day'", "now()")
+				b.BetweenSymmetric("score", 10, 1)
+				b.Field("price").Between().Value(1, 2)
+				b.Field("price").NotBetween().Field("min_price", "max_price")
+				b.Field("x").BetweenSymmetric().Raw(1, 2)
+			},
+			`where (age between $1 and $2
+				and created_at not between now() - interval '1 day' and now()
+				and score between symmetric $3 and $4
+				and price between $5 and $6
+				and price not between min_price and max_price
+				and x between symmetric 1 and 2)`,
+			[]any{18, 60, 10, 1, 1, 2},
+		},
+		{
+			"distinct",
+			func(b pgstmt.Cond) {
+				b.IsDistinctFrom("a", 1)
+				b.IsNotDistinctFromRaw("b", "c")
+				b.Field("d").IsDistinctFrom().Field("e")
+				b.Field("f").IsNotDistinctFrom().Value(nil)
+			},
+			`where (a is distinct from $1
+				and b is not distinct from c
+				and d is distinct from e
+				and f is not distinct from $2)`,
+			[]any{1, nil},
+		},
+		{
+			"pattern",
+			func(b pgstmt.Cond) {
+				b.NotLike("a", "%x")
+				b.NotILikeRaw("b", "c")
+				b.SimilarTo("c", "%(b|d)%")
+				b.NotSimilarTo("d", "x")
+				b.Regex("e", "^a")
+				b.IRegex("f", "^b")
+				b.NotRegexRaw("g", "h")
+				b.NotIRegex("h", "^c")
+				b.Field("i").NotLike().Value("%")
+				b.Field("j").Regex().Raw("'x'")
+			},
+			`where (a not like $1
+				and b not ilike c
+				and c similar to $2
+				and d not similar to $3
+				and e ~ $4
+				and f ~* $5
+				and g !~ h
+				and h !~* $6
+				and i not like $7
+				and j ~ 'x')`,
+			[]any{"%x", "%(b|d)%", "x", "^a", "^b", "^c", "%"},
+		},
+		{
+			"is",
+			func(b pgstmt.Cond) {
+				b.IsTrue("a")
+				b.IsNotTrue("b")
+				b.IsFalse("c")
+				b.IsNotFalse("d")
+				b.IsUnknown("e")
+				b.IsNotUnknown("f")
+				b.Field("g").IsTrue()
+				b.Field("h").IsNotUnknown()
+			},
+			`where (a is true and b is not true and c is false and d is not false
+				and e is unknown and f is not unknown and g is true and h is not unknown)`,
+			nil,
+		},
+		{
+			"exists",
+			func(b pgstmt.Cond) {
+				b.Exists(func(b pgstmt.SelectStatement) {
+					b.Columns("1")
+					b.From("orders o")
+					b.Where(func(b pgstmt.Cond) {
+						b.EqRaw("o.user_id", "u.id")
+						b.Eq("o.status", "paid")
+					})
+				})
+				b.NotExists(func(b pgstmt.SelectStatement) {
+					b.Columns("1")
+					b.From("bans")
+					b.Where(func(b pgstmt.Cond) {
+						b.EqRaw("bans.user_id", "u.id")
+					})
+				})
+			},
+			`where (exists (select 1 from orders o where (o.user_id = u.id and o.status = $1))
+				and not exists (select 1 from bans where (bans.user_id = u.id)))`,
+			[]any{"paid"},
+		},
+	}
+
+	for _, tC := range cases {
+		t.Run(tC.name, func(t *testing.T) {
+			q, args := pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("*")
+				b.From("users u")
+				b.Where(tC.cond)
+			}).SQL()
+			assert.Equal(t, stripSpace("select * from users u "+tC.query), q)
+			assert.EqualValues(t, tC.args, args)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package pgstmt
+
+import (
+	"context"
+
+	"github.com/acoshift/pgsql"
+)
+
+func (st *selectStmt) BuildCount() *Result {
+	x := st.clone()
+	x.ResetOrderBy()
+	x.ResetLimit()
+
+	if x.distinct != nil || !x.groupBy.empty() || !x.having.empty() {
+		var c selectStmt
+		c.columns.push("count(*)")
+		c.from.push(withGroup(" ", paren(x.make()), "t"))
+		return newResult(c.make())
+	}
+
+	x.columns = group{}
+	x.columns.push("count(*)")
+	return newResult(x.make())
+}
+
+func (st *selectStmt) BuildWithTotal() *Result {
+	x := st.clone()
+	if st.distinct != nil {
+		// count(*) over() counts rows before distinct,
+		// count distinct rows in uncorrelated subquery, evaluated once in the same snapshot
+		x.columns.push(st.BuildCount())
+		return newResult(x.make())
+	}
+	x.columns.push("count(*) over()")
+	return newResult(x.make())
+}
+
+func (st *selectStmt) IterWithTotal(ctx context.Context, iter pgsql.Iterator) (total int64, err error) {
+	n := 0
+	err = st.BuildWithTotal().IterWith(ctx, func(scan pgsql.Scanner) error {
+		n++
+		return iter(func(dest ...any) error {
+			// do not append into caller's slice
+			xs := make([]any, 0, len(dest)+1)
+			xs = append(xs, dest...)
+			return scan(append(xs, &total)...)
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// limit is zero or offset is out of range, total is unknown,
+	// count in another query, rows are empty so there is nothing to be inconsistent with
+	if n == 0 && (st.limit != nil || st.fetch != nil || st.offset != nil) {
+		err = st.BuildCount().QueryRowWith(ctx).Scan(&total)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
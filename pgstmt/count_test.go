@@ -0,0 +1,225 @@
+package pgstmt_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql"
+	"github.com/acoshift/pgsql/pgctx"
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestBuildCount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Replace columns", func(t *testing.T) {
+		b := pgstmt.NewSelect()
+		b.Columns("id", "name")
+		b.From("users")
+		b.Where(func(b pgstmt.Cond) {
+			b.Eq("status", "active")
+		})
+		b.OrderBy("id")
+		b.Limit(10)
+		b.Offset(20)
+
+		q, args := b.BuildCount().SQL()
+		assert.Equal(t, "select count(*) from users where (status = $1)", q)
+		assert.EqualValues(t, []any{"active"}, args)
+
+		// original statement is not modified
+		q, _ = b.Build().SQL()
+		assert.Equal(t, "select id, name from users where (status = $1) order by id limit 10 offset 20", q)
+	})
+
+	t.Run("Wrap group by", func(t *testing.T) {
+		b := pgstmt.NewSelect()
+		b.Columns("user_id", "count(*)")
+		b.From("orders")
+		b.GroupBy("user_id")
+		b.OrderBy("user_id")
+		b.LimitArg(10)
+
+		q, args := b.BuildCount().SQL()
+		assert.Equal(t, "select count(*) from (select user_id, count(*) from orders group by (user_id)) t", q)
+		assert.Empty(t, args)
+	})
+
+	t.Run("Wrap distinct", func(t *testing.T) {
+		b := pgstmt.NewSelect()
+		b.Distinct()
+		b.Columns("user_id")
+		b.From("orders")
+		b.Where(func(b pgstmt.Cond) {
+			b.Gt("amount", 100)
+		})
+
+		q, args := b.BuildCount().SQL()
+		assert.Equal(t, "select count(*) from (select distinct user_id from orders where (amount > $1)) t", q)
+		assert.EqualValues(t, []any{100}, args)
+	})
+
+	t.Run("With total", func(t *testing.T) {
+		b := pgstmt.NewSelect()
+		b.Columns("id")
+		b.From("users")
+		b.OrderBy("id")
+		b.Limit(10)
+
+		q, _ := b.BuildWithTotal().SQL()
+		assert.Equal(t, "select id, count(*) over() from users order by id limit 10", q)
+	})
+
+	t.Run("With total distinct", func(t *testing.T) {
+		b := pgstmt.NewSelect()
+		b.Distinct()
+		b.Columns("user_id")
+		b.From("orders")
+		b.Where(func(b pgstmt.Cond) {
+			b.Gt("amount", 100)
+		})
+		b.OrderBy("user_id")
+		b.Limit(10)
+
+		q, args := b.BuildWithTotal().SQL()
+		assert.Equal(t, stripSpace(`
+			select distinct user_id, (select count(*) from (select distinct user_id from orders where (amount > $1)) t)
+			from orders
+			where (amount > $2)
+			order by user_id
+			limit 10
+		`), q)
+		assert.EqualValues(t, []any{100, 100}, args)
+	})
+}
+
+func TestIterWithTotal(t *testing.T) {
+	t.Parallel()
+
+	newSelect := func(offset int64) pgstmt.SelectBuilder {
+		b := pgstmt.NewSelect()
+		b.Columns("id")
+		b.From("users")
+		b.OrderBy("id")
+		b.Limit(2)
+		b.Offset(offset)
+		return b
+	}
+
+	t.Run("One round trip", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		ctx := pgctx.NewContext(context.Background(), db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("select id, count(*) over() from users order by id limit 2 offset 0")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "count"}).AddRow(1, 5).AddRow(2, 5))
+
+		var ids []int64
+		total, err := newSelect(0).IterWithTotal(ctx, func(scan pgsql.Scanner) error {
+			var id int64
+			err := scan(&id)
+			ids = append(ids, id)
+			return err
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 5, total)
+		assert.Equal(t, []int64{1, 2}, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Offset out of range", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		ctx := pgctx.NewContext(context.Background(), db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("select id, count(*) over() from users order by id limit 2 offset 10")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "count"}))
+		mock.ExpectQuery(regexp.QuoteMeta("select count(*) from users")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+		total, err := newSelect(10).IterWithTotal(ctx, func(scan pgsql.Scanner) error {
+			return scan(new(int64))
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 5, total)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Limit zero", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		ctx := pgctx.NewContext(context.Background(), db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("select id, count(*) over() from users order by id limit 0")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "count"}))
+		mock.ExpectQuery(regexp.QuoteMeta("select count(*) from users")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+		b := pgstmt.NewSelect()
+		b.Columns("id")
+		b.From("users")
+		b.OrderBy("id")
+		b.Limit(0)
+
+		total, err := b.IterWithTotal(ctx, func(scan pgsql.Scanner) error {
+			return scan(new(int64))
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 5, total)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Do not modify dest", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		ctx := pgctx.NewContext(context.Background(), db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("select id, count(*) over() from users order by id limit 2 offset 0")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "count"}).AddRow(1, 5))
+
+		var id, other int64
+		dest := make([]any, 1, 2)
+		dest[0] = &id
+		buf := append(dest, &other)
+		total, err := newSelect(0).IterWithTotal(ctx, func(scan pgsql.Scanner) error {
+			return scan(dest...)
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 5, total)
+		assert.EqualValues(t, 1, id)
+		assert.Same(t, &other, buf[1])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Distinct", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		ctx := pgctx.NewContext(context.Background(), db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("select distinct user_id, (select count(*) from (select distinct user_id from orders) t) from orders order by user_id limit 2")).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "count"}).AddRow(1, 3).AddRow(2, 3))
+
+		b := pgstmt.NewSelect()
+		b.Distinct()
+		b.Columns("user_id")
+		b.From("orders")
+		b.OrderBy("user_id")
+		b.Limit(2)
+
+		var ids []int64
+		total, err := b.IterWithTotal(ctx, func(scan pgsql.Scanner) error {
+			var id int64
+			err := scan(&id)
+			ids = append(ids, id)
+			return err
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 3, total)
+		assert.Equal(t, []int64{1, 2}, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,132 @@
+package pgstmt
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lib/pq"
+
+	"github.com/acoshift/pgsql/internal/sqlscan"
+)
+
+const debugPrefix = "/* pgstmt: debug only, DO NOT execute */ "
+
+// DebugString returns query with inlined arguments,
+// prefixed with a comment marking it as debug only.
+//
+// The result is for debugging only, DO NOT execute it.
+func (r *Result) DebugString() string {
+	return debugPrefix + r.Interpolate()
+}
+
+// Interpolate returns query with arguments inlined as sql literals.
+//
+// The result is for debugging only (ex. paste into psql),
+// it is not guaranteed to be safe, DO NOT execute it.
+func (r *Result) Interpolate() string {
+	return interpolate(r.query, r.args)
+}
+
+func interpolate(query string, args []any) string {
+	var b strings.Builder
+	for i := 0; i < len(query); {
+		if j := sqlscan.Skip(query, i); j > i {
+			b.WriteString(query[i:j])
+			i = j
+			continue
+		}
+		if n, j, ok := sqlscan.Placeholder(query, i); ok && n >= 1 && n <= len(args) {
+			b.WriteString(literal(args[n-1]))
+			i = j
+			continue
+		}
+		b.WriteByte(query[i])
+		i++
+	}
+	return b.String()
+}
+
+// literal converts argument into sql literal
+func literal(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case named: // unbound named argument
+		return ":" + v.name
+	case driver.Valuer:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return "null"
+		}
+		x, err := v.Value()
+		if err != nil {
+			return "null /* " + strings.ReplaceAll(err.Error(), "*/", "* /") + " */"
+		}
+		// valuer usually returns []byte as text (ex. json)
+		if p, ok := x.([]byte); ok && utf8.Valid(p) {
+			return pq.QuoteLiteral(string(p))
+		}
+		return literal(x)
+	case string:
+		return pq.QuoteLiteral(v)
+	case []byte:
+		if v == nil {
+			return "null"
+		}
+		return `'\x` + hex.EncodeToString(v) + `'::bytea`
+	case time.Time:
+		return pq.QuoteLiteral(string(pq.FormatTimestamp(v)))
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return convertToString(v, false)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case json.Marshaler:
+		return jsonLiteral(v)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "null"
+		}
+		return literal(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return "null"
+		}
+		if rv.Len() == 0 {
+			return "'{}'"
+		}
+		xs := make([]string, rv.Len())
+		for i := range xs {
+			xs[i] = literal(rv.Index(i).Interface())
+		}
+		return "array[" + strings.Join(xs, ", ") + "]"
+	case reflect.Map, reflect.Struct:
+		return jsonLiteral(v)
+	case reflect.String:
+		return pq.QuoteLiteral(rv.String())
+	}
+	return pq.QuoteLiteral(convertToString(v, false))
+}
+
+func jsonLiteral(v any) string {
+	p, err := json.Marshal(v)
+	if err != nil {
+		return "null /* " + strings.ReplaceAll(err.Error(), "*/", "* /") + " */"
+	}
+	return pq.QuoteLiteral(string(p))
+}
@@ -0,0 +1,118 @@
+package pgstmt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql"
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestResult_Interpolate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		result *pgstmt.Result
+		query  string
+	}{
+		{
+			"scalar",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("*")
+				b.From("users")
+				b.Where(func(b pgstmt.Cond) {
+					b.Eq("id", 1)
+					b.Eq("name", "O'Reilly")
+					b.Eq("is_active", true)
+					b.Eq("score", 1.5)
+					b.IsNull("deleted_at")
+				})
+			}),
+			`select * from users where (id = 1 and name = 'O''Reilly' and is_active = true and score = 1.5 and deleted_at is null)`,
+		},
+		{
+			"null",
+			pgstmt.Update(func(b pgstmt.UpdateStatement) {
+				b.Table("users")
+				b.Set("name").To(nil)
+				b.Set("image").To(pgsql.NullString(new(string)))
+			}),
+			`update users set name = null, image = null`,
+		},
+		{
+			"time",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("1")
+				b.Where(func(b pgstmt.Cond) {
+					b.Gt("created_at", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+				})
+			}),
+			`select 1 where (created_at > '2020-01-02 03:04:05Z')`,
+		},
+		{
+			"bytea",
+			pgstmt.Insert(func(b pgstmt.InsertStatement) {
+				b.Into("files")
+				b.Columns("data")
+				b.Value([]byte{0xde, 0xad, 0xbe, 0xef})
+			}),
+			`insert into files (data) values ('\xdeadbeef'::bytea)`,
+		},
+		{
+			"array",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("1")
+				b.Where(func(b pgstmt.Cond) {
+					b.Eq("id", pgstmt.Any([]int64{1, 2}))
+					b.Eq("name", pgstmt.Any([]string{"a", "b"}))
+					b.Eq("tag", pgstmt.Any(pq.Array([]string{"x"})))
+				})
+			}),
+			`select 1 where (id = any(array[1, 2]) and name = any(array['a', 'b']) and tag = any('{"x"}'))`,
+		},
+		{
+			"json",
+			pgstmt.Insert(func(b pgstmt.InsertStatement) {
+				b.Into("logs")
+				b.Columns("data", "meta")
+				b.Value(pgsql.JSON(map[string]any{"a": 1}), struct {
+					B string `json:"b"`
+				}{"x"})
+			}),
+			`insert into logs (data, meta) values ('{"a":1}', '{"b":"x"}')`,
+		},
+		{
+			"placeholder in literal",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns(pgstmt.NotArg("$1"), pgstmt.Arg("x"))
+			}),
+			`select '$1', 'x'`,
+		},
+		{
+			"placeholder in comment and dollar quoted",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns(pgstmt.Arg("x"), "/* $1 */ $$ $1 $$", "$t$ $1 $t$")
+			}),
+			`select 'x', /* $1 */ $$ $1 $$, $t$ $1 $t$`,
+		},
+	}
+
+	for _, tC := range cases {
+		t.Run(tC.name, func(t *testing.T) {
+			assert.Equal(t, tC.query, tC.result.Interpolate())
+		})
+	}
+}
+
+func TestResult_DebugString(t *testing.T) {
+	t.Parallel()
+
+	s := pgstmt.Select(func(b pgstmt.SelectStatement) {
+		b.Columns(pgstmt.Arg(1))
+	}).DebugString()
+	assert.Equal(t, "/* pgstmt: debug only, DO NOT execute */ select 1", s)
+}
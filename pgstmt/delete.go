@@ -4,7 +4,7 @@ package pgstmt
 func Delete(f func(b DeleteStatement)) *Result {
 	var st deleteStmt
 	f(&st)
-	return newResult(build(st.make()))
+	return newResult(st.make())
 }
 
 type DeleteStatement interface {
@@ -28,16 +28,13 @@ func (st *deleteStmt) Where(f func(b Cond)) {
 }
 
 func (st *deleteStmt) Returning(col ...string) {
-	st.returning.pushString(col...)
+	st.returning.pushIdent(col...)
 }
 
 func (st *deleteStmt) make() *buffer {
 	var b buffer
-	b.push("delete from", st.from)
-	if !st.where.empty() {
-		b.push("where")
-		b.push(st.where.build()...)
-	}
+	b.push("delete from", ident{value: st.from, alias: true})
+	b.push(&scopeCond{"where", []ident{{value: st.from, alias: true}}, &st.where})
 	if !st.returning.empty() {
 		b.push("returning")
 		b.push(&st.returning)
@@ -0,0 +1,125 @@
+package pgstmt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgctx"
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestExpr(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		result *pgstmt.Result
+		query  string
+		args   []any
+	}{
+		{
+			"select",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("id", pgstmt.Expr("coalesce(name, ?) as name", "unknown"))
+				b.From("users u")
+				b.LeftJoin("orders o").On(func(b pgstmt.Cond) {
+					b.EqRaw("o.user_id", "u.id")
+					b.Expr("o.created_at > now() - ?::interval", "1 day")
+				})
+				b.Where(func(b pgstmt.Cond) {
+					b.Eq("status", 1)
+					b.Expr("data ?? 'key' and lower(email) = lower(?)", "a@b.c")
+					b.Eq(pgstmt.Expr("date_part(?, created_at)", "year"), 2020)
+				})
+				b.GroupByExpr("id", pgstmt.Expr("date_trunc(?, created_at)", "day"))
+				b.OrderByExpr(pgstmt.Expr("id = ?", 3)).Desc()
+			}),
+			`select id, coalesce(name, $1) as name
+			from users u
+			left join orders o on (o.user_id = u.id and o.created_at > now() - $2::interval)
+			where (status = $3 and data ? 'key' and lower(email) = lower($4) and date_part($5, created_at) = $6)
+			group by (id, date_trunc($7, created_at))
+			order by id = $8 desc`,
+			[]any{"unknown", "1 day", 1, "a@b.c", "year", 2020, "day", 3},
+		},
+		{
+			"update",
+			pgstmt.Update(func(b pgstmt.UpdateStatement) {
+				b.Table("users")
+				b.Set("name").To("x")
+				b.Set("score").ToRaw(pgstmt.Expr("score + ?", 10))
+				b.Set("tags").To(pgstmt.Expr("array_append(tags, ?)", pgstmt.Named("tag")))
+				b.Where(func(b pgstmt.Cond) {
+					b.Expr("id = ? and '?' = ?", 1, pgstmt.Raw("'?'"))
+				})
+			}),
+			`update users set name = $1, score = score + $2, tags = array_append(tags, $3) where (id = $4 and '?' = '?')`,
+			nil,
+		},
+		{
+			"raw",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("id")
+				b.From("users")
+				b.Where(func(b pgstmt.Cond) {
+					b.EqRaw("a", pgstmt.Expr("x + ?", 1))
+					b.OpRaw("b", "@>", pgstmt.Expr("array[?]", 2))
+					b.BetweenRaw("c", pgstmt.Expr("? - 1", 3), pgstmt.Expr("? + 1", 3))
+					b.InRaw("d", pgstmt.Expr("lower(?)", "x"))
+					b.Field("e").Gt().Raw(pgstmt.Expr("now() - ?::interval", "1 day"))
+					b.Field("f").Between().Raw(pgstmt.Expr("?", 4), "g")
+				})
+			}),
+			`select id from users
+			where (a = x + $1
+				and b @> array[$2]
+				and c between $3 - 1 and $4 + 1
+				and d in (lower($5))
+				and e > now() - $6::interval
+				and f between $7 and g)`,
+			[]any{1, 2, 3, 3, "x", "1 day", 4},
+		},
+		{
+			"skip comments and dollar quoted",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns(pgstmt.Expr("? /* ? */, $$ ? $$, $t$ ? $t$, E'\\' ?', ?", 1, 2))
+			}),
+			`select $1 /* ? */, $$ ? $$, $t$ ? $t$, E'\' ?', $2`,
+			[]any{1, 2},
+		},
+	}
+
+	for _, tC := range cases {
+		t.Run(tC.name, func(t *testing.T) {
+			q, args := tC.result.SQL()
+			assert.Equal(t, stripSpace(tC.query), q)
+			if tC.args != nil {
+				assert.EqualValues(t, tC.args, args)
+			}
+		})
+	}
+}
+
+func TestExpr_MismatchArgs(t *testing.T) {
+	t.Parallel()
+
+	build := func(x any) *pgstmt.Result {
+		return pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("id", x)
+			b.From("users")
+		})
+	}
+
+	assert.Error(t, build(pgstmt.Expr("a = ? and b = ?", 1)).Err())
+	assert.Error(t, build(pgstmt.Expr("a = ?", 1, 2)).Err())
+	assert.NoError(t, build(pgstmt.Expr("a ?? 'b' and c = ? /* ? */", 1)).Err())
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	_, err = build(pgstmt.Expr("a = ?")).QueryWith(pgctx.NewContext(context.Background(), db))
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,156 @@
+package pgstmt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		result *pgstmt.Result
+		query  string
+	}{
+		{
+			"select",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("id", "name")
+				b.ColumnSelect(func(b pgstmt.SelectStatement) {
+					b.Columns("count(*)")
+					b.From("orders o")
+					b.Where(func(b pgstmt.Cond) {
+						b.EqRaw("o.user_id", "u.id")
+					})
+				}, "orders")
+				b.From("users u")
+				b.LeftJoin("profiles p").On(func(b pgstmt.Cond) {
+					b.EqRaw("p.user_id", "u.id")
+				})
+				b.Where(func(b pgstmt.Cond) {
+					b.Eq("u.status", "active")
+					b.InSelect("u.id", func(b pgstmt.SelectStatement) {
+						b.Columns("user_id")
+						b.From("admins")
+						b.Where(func(b pgstmt.Cond) {
+							b.Eq("level", 1)
+						})
+					})
+				})
+				b.OrderBy("u.id").Desc()
+				b.Limit(10)
+			}),
+			"select id, name, (\n" +
+				"\tselect count(*)\n" +
+				"\tfrom orders o\n" +
+				"\twhere (o.user_id = u.id)\n" +
+				") orders\n" +
+				"from users u\n" +
+				"left join profiles p on (p.user_id = u.id)\n" +
+				"where (u.status = $1 and u.id in (\n" +
+				"\tselect user_id\n" +
+				"\tfrom admins\n" +
+				"\twhere (level = $2)\n" +
+				"))\n" +
+				"order by u.id desc\n" +
+				"limit 10",
+		},
+		{
+			"union",
+			pgstmt.Union(func(b pgstmt.UnionStatement) {
+				b.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("id")
+					b.From("table1")
+				})
+				b.AllSelect(func(b pgstmt.SelectStatement) {
+					b.Columns("id")
+					b.From("table2")
+					b.Where(func(b pgstmt.Cond) {
+						b.Eq("id", 1)
+					})
+				})
+				b.OrderBy("id")
+			}),
+			"(\n" +
+				"\tselect id\n" +
+				"\tfrom table1\n" +
+				")\n" +
+				"union all (\n" +
+				"\tselect id\n" +
+				"\tfrom table2\n" +
+				"\twhere (id = $1)\n" +
+				")\n" +
+				"order by id",
+		},
+		{
+			"embedded result",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				active := pgstmt.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("id")
+					b.From("users")
+					b.Where(func(b pgstmt.Cond) {
+						b.Eq("status", "active")
+					})
+				})
+				b.With("x", active)
+				b.Columns("id")
+				b.FromResult(pgstmt.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("id")
+					b.From("x")
+				}), "t")
+				b.Where(func(b pgstmt.Cond) {
+					b.InResult("id", active)
+				})
+			}),
+			"with x as (\n" +
+				"\tselect id\n" +
+				"\tfrom users\n" +
+				"\twhere (status = $1)\n" +
+				")\n" +
+				"select id\n" +
+				"from (\n" +
+				"\tselect id\n" +
+				"\tfrom x\n" +
+				") t\n" +
+				"where (id in (\n" +
+				"\tselect id\n" +
+				"\tfrom users\n" +
+				"\twhere (status = $2)\n" +
+				"))",
+		},
+		{
+			"insert",
+			pgstmt.Insert(func(b pgstmt.InsertStatement) {
+				b.Into("users")
+				b.Columns("id", "name")
+				b.Value(1, "name")
+				b.OnConflictIndex("id").DoUpdate(func(b pgstmt.UpdateStatement) {
+					b.Set("name").ToRaw("excluded.name")
+				})
+				b.Returning("id")
+			}),
+			"insert into users (id, name)\n" +
+				"values ($1, $2)\n" +
+				"on conflict (id)\n" +
+				"do update\n" +
+				"set name = excluded.name\n" +
+				"returning id",
+		},
+	}
+
+	for _, tC := range cases {
+		t.Run(tC.name, func(t *testing.T) {
+			q, args := pgstmt.Format(tC.result).SQL()
+			assert.Equal(t, tC.query, q)
+
+			_, expectedArgs := tC.result.SQL()
+			assert.Equal(t, expectedArgs, args)
+
+			assert.Equal(t, stripSpace(tC.result.Interpolate()), stripSpace(pgstmt.Format(tC.result).Interpolate()))
+		})
+	}
+}
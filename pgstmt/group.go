@@ -1,17 +1,27 @@
 package pgstmt
 
 type group struct {
-	q   []any
-	sep string
+	q      []any
+	sep    string
+	concat bool // join without separator
 }
 
 func (b *group) getSep() string {
+	if b.concat {
+		return ""
+	}
 	if b.sep == "" {
 		return ", "
 	}
 	return b.sep
 }
 
+// clone returns copy of group, items are shared
+func (b group) clone() group {
+	b.q = append([]any(nil), b.q...)
+	return b
+}
+
 func (b *group) empty() bool {
 	return len(b.q) == 0
 }
@@ -26,6 +36,18 @@ func (b *group) pushString(q ...string) {
 	}
 }
 
+func (b *group) pushIdent(q ...string) {
+	for _, x := range q {
+		b.q = append(b.q, ident{value: x})
+	}
+}
+
+func (b *group) pushTable(q ...string) {
+	for _, x := range q {
+		b.q = append(b.q, ident{value: x, alias: true})
+	}
+}
+
 func withGroup(sep string, q ...any) any {
 	var g group
 	g.sep = sep
@@ -38,6 +60,16 @@ type parenGroup struct {
 	prefix string
 }
 
+// hasStatement checks is group contains a statement (subquery)
+func (b *parenGroup) hasStatement() bool {
+	for _, x := range b.q {
+		if _, ok := x.(*buffer); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func paren(q ...any) any {
 	var p parenGroup
 	p.push(q...)
@@ -50,6 +82,19 @@ func parenString(q ...string) any {
 	return &p
 }
 
+func concat(q ...any) any {
+	var g group
+	g.concat = true
+	g.push(q...)
+	return &g
+}
+
+func parenIdent(q ...string) any {
+	var p parenGroup
+	p.pushIdent(q...)
+	return &p
+}
+
 func withParen(sep string, q ...any) any {
 	var p parenGroup
 	p.sep = sep
@@ -0,0 +1,150 @@
+package pgstmt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/acoshift/pgsql/internal/sqlscan"
+)
+
+// Ident quotes identifier parts and joins them with dot,
+// ex. Ident("public", "User") returns "public"."User"
+func Ident(parts ...string) string {
+	var q []string
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		q = append(q, pq.QuoteIdentifier(p))
+	}
+	return strings.Join(q, ".")
+}
+
+// Strict returns new result built with strict identifier mode.
+//
+// In strict mode, all identifier positions (table names, insert columns,
+// set columns, returning, conflict targets and join using)
+// must be valid identifiers, optionally schema-qualified,
+// and unquoted identifiers will be folded to lower case and quoted.
+// Table names may have an alias (ex. "users u" or "users as u").
+func Strict(r *Result) (*Result, error) {
+	if r.b == nil {
+		return r, nil
+	}
+
+	x := renderer{pretty: r.pretty, strict: true, scope: r.scope, scopeArg: len(r.args)}
+	query := x.renderResult(r.b)
+	if x.err != nil {
+		return nil, x.err
+	}
+	s := *r
+	s.query = query
+	s.strict = true
+	return &s, nil
+}
+
+// ident marks value in identifier position
+type ident struct {
+	value string
+	alias bool // allow alias, ex. table name
+}
+
+func (x ident) quote() (string, error) {
+	s := strings.TrimSpace(x.value)
+
+	name, rest, err := scanIdentChain(s)
+	if err != nil {
+		return "", err
+	}
+	if rest == "" {
+		return name, nil
+	}
+	if !x.alias || rest[0] != ' ' {
+		return "", fmt.Errorf("pgstmt: invalid identifier %q", x.value)
+	}
+
+	rest = strings.TrimSpace(rest)
+	as := ""
+	if p := strings.Fields(rest); len(p) == 2 && strings.EqualFold(p[0], "as") {
+		as = "as "
+		rest = p[1]
+	}
+	alias, tail, err := scanIdent(rest)
+	if err != nil || tail != "" {
+		return "", fmt.Errorf("pgstmt: invalid identifier %q", x.value)
+	}
+	return name + " " + as + alias, nil
+}
+
+// scanIdentChain scans dot separated identifiers, last part can be *
+func scanIdentChain(s string) (name, rest string, err error) {
+	var parts []string
+	for {
+		if strings.HasPrefix(s, "*") {
+			parts = append(parts, "*")
+			s = s[1:]
+			break
+		}
+
+		var p string
+		p, s, err = scanIdent(s)
+		if err != nil {
+			return "", "", err
+		}
+		parts = append(parts, p)
+
+		if !strings.HasPrefix(s, ".") {
+			break
+		}
+		s = s[1:]
+	}
+	return strings.Join(parts, "."), s, nil
+}
+
+// scanIdent scans single identifier, returns quoted identifier
+func scanIdent(s string) (name, rest string, err error) {
+	if s == "" {
+		return "", "", fmt.Errorf("pgstmt: empty identifier")
+	}
+
+	// quoted identifier
+	if s[0] == '"' {
+		for i := 1; i < len(s); i++ {
+			if s[i] != '"' {
+				continue
+			}
+			if i+1 < len(s) && s[i+1] == '"' {
+				i++
+				continue
+			}
+			if i == 1 {
+				return "", "", fmt.Errorf("pgstmt: empty identifier")
+			}
+			return s[:i+1], s[i+1:], nil
+		}
+		return "", "", fmt.Errorf("pgstmt: unterminated quoted identifier %q", s)
+	}
+
+	i := 0
+	for i < len(s) && sqlscan.IsNameChar(s[i], i == 0) {
+		i++
+	}
+	if i == 0 {
+		return "", "", fmt.Errorf("pgstmt: invalid identifier %q", s)
+	}
+	// unquoted identifier is case-insensitive, fold to lower case as postgres does
+	return pq.QuoteIdentifier(foldIdent(s[:i])), s[i:], nil
+}
+
+// foldIdent converts ascii letters to lower case, non-ascii characters are kept as postgres does
+func foldIdent(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + ('a' - 'A')
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,119 @@
+package pgstmt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestIdent(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `"user"`, pgstmt.Ident("user"))
+	assert.Equal(t, `"public"."User"`, pgstmt.Ident("public", "User"))
+	assert.Equal(t, `"a""b"`, pgstmt.Ident(`a"b`))
+
+	q, _ := pgstmt.Select(func(b pgstmt.SelectStatement) {
+		b.Columns(pgstmt.Ident("order"))
+		b.From(pgstmt.Ident("public", "user"))
+	}).SQL()
+	assert.Equal(t, `select "order" from "public"."user"`, q)
+}
+
+func TestStrict(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		result *pgstmt.Result
+		query  string
+	}{
+		{
+			"select",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("u.id", "count(*)")
+				b.From("public.users u")
+				b.LeftJoin(`"Order" as o`).Using("user_id")
+				b.Where(func(b pgstmt.Cond) {
+					b.Eq("u.id", 1)
+				})
+			}),
+			`select u.id, count(*) from "public"."users" "u" left join "Order" as "o" using ("user_id") where (u.id = $1)`,
+		},
+		{
+			"insert",
+			pgstmt.Insert(func(b pgstmt.InsertStatement) {
+				b.Into("user")
+				b.Columns("id", "order")
+				b.Value(1, 2)
+				b.OnConflictIndex("id").DoUpdate(func(b pgstmt.UpdateStatement) {
+					b.Set("order").ToRaw("excluded.order")
+				})
+				b.Returning("*")
+			}),
+			`insert into "user" ("id", "order") values ($1, $2) on conflict ("id") do update set "order" = excluded.order returning *`,
+		},
+		{
+			"update",
+			pgstmt.Update(func(b pgstmt.UpdateStatement) {
+				b.Table("user")
+				b.Set("name").To("x")
+				b.Returning("user.*")
+			}),
+			`update "user" set "name" = $1 returning "user".*`,
+		},
+		{
+			"delete",
+			pgstmt.Delete(func(b pgstmt.DeleteStatement) {
+				b.From(pgstmt.Ident("user"))
+				b.Returning("id")
+			}),
+			`delete from "user" returning "id"`,
+		},
+		{
+			"fold case",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("1")
+				b.From(`Public.Users U`)
+				b.Join(`"Orders" O`).Using("userId")
+			}),
+			`select 1 from "public"."users" "u" join "Orders" "o" using ("userid")`,
+		},
+	}
+
+	for _, tC := range cases {
+		t.Run(tC.name, func(t *testing.T) {
+			r, err := pgstmt.Strict(tC.result)
+			if assert.NoError(t, err) {
+				q, args := r.SQL()
+				assert.Equal(t, tC.query, q)
+				_, expectedArgs := tC.result.SQL()
+				assert.Equal(t, expectedArgs, args)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, table := range []string{
+			"users; drop table users",
+			"users u x",
+			`"users`,
+			"",
+			"1users",
+		} {
+			_, err := pgstmt.Strict(pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("1")
+				b.From(table)
+			}))
+			assert.Error(t, err, table)
+		}
+
+		_, err := pgstmt.Strict(pgstmt.Insert(func(b pgstmt.InsertStatement) {
+			b.Into("users")
+			b.Columns("id) values (1); --")
+		}))
+		assert.Error(t, err)
+	})
+}
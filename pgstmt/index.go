@@ -0,0 +1,126 @@
+package pgstmt
+
+// CreateIndex builds create index statement
+func CreateIndex(f func(b CreateIndexStatement)) *Result {
+	var st createIndexStmt
+	f(&st)
+	return newResult(newDDL(st.make()))
+}
+
+// CreateIndexStatement is the create index statement builder.
+//
+// Values in where condition are inlined as sql literals.
+type CreateIndexStatement interface {
+	Name(name string)
+	Unique()
+	Concurrently()
+	IfNotExists()
+	On(table string)
+
+	// Using sets index method, ex. btree, gin
+	Using(method string)
+
+	// Column adds index column, string is column name,
+	// other values (ex. Raw("lower(email)")) are expression
+	Column(col any) OrderBy
+	Columns(col ...any)
+
+	Include(col ...string)
+	Where(f func(b Cond))
+}
+
+type createIndexStmt struct {
+	name         string
+	unique       bool
+	concurrently bool
+	ifNotExists  bool
+	table        string
+	method       string
+	columns      parenGroup
+	include      []string
+	where        cond
+}
+
+func (st *createIndexStmt) Name(name string) {
+	st.name = name
+}
+
+func (st *createIndexStmt) Unique() {
+	st.unique = true
+}
+
+func (st *createIndexStmt) Concurrently() {
+	st.concurrently = true
+}
+
+func (st *createIndexStmt) IfNotExists() {
+	st.ifNotExists = true
+}
+
+func (st *createIndexStmt) On(table string) {
+	st.table = table
+}
+
+func (st *createIndexStmt) Using(method string) {
+	st.method = method
+}
+
+func (st *createIndexStmt) Column(col any) OrderBy {
+	p := orderBy{
+		col: indexColumn(col),
+	}
+	st.columns.push(&p)
+	return &p
+}
+
+func (st *createIndexStmt) Columns(col ...any) {
+	for _, c := range col {
+		st.columns.push(indexColumn(c))
+	}
+}
+
+func indexColumn(col any) any {
+	if s, ok := col.(string); ok {
+		return ident{value: s}
+	}
+	return paren(col)
+}
+
+func (st *createIndexStmt) Include(col ...string) {
+	st.include = append(st.include, col...)
+}
+
+func (st *createIndexStmt) Where(f func(b Cond)) {
+	f(&st.where)
+}
+
+func (st *createIndexStmt) make() *buffer {
+	var b buffer
+	b.push("create")
+	if st.unique {
+		b.push("unique")
+	}
+	b.push("index")
+	if st.concurrently {
+		b.push("concurrently")
+	}
+	if st.ifNotExists {
+		b.push("if not exists")
+	}
+	if st.name != "" {
+		b.push(ident{value: st.name})
+	}
+	b.push("on", ident{value: st.table})
+	if st.method != "" {
+		b.push("using", st.method)
+	}
+	b.push(&st.columns)
+	if len(st.include) > 0 {
+		b.push("include", parenIdent(st.include...))
+	}
+	if !st.where.empty() {
+		b.push("where")
+		b.push(st.where.build()...)
+	}
+	return &b
+}
@@ -1,10 +1,15 @@
 package pgstmt
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Insert builds insert statement
 func Insert(f func(b InsertStatement)) *Result {
 	var st insertStmt
 	f(&st)
-	return newResult(build(st.make()))
+	return newResult(st.make())
 }
 
 // InsertStatement is the insert statement builder
@@ -48,6 +53,20 @@ type ConflictTarget interface {
 type ConflictAction interface {
 	DoNothing()
 	DoUpdate(f func(b UpdateStatement))
+
+	// DoUpdateExcluded sets columns to excluded values,
+	// empty col for all insert's columns except conflict target columns
+	DoUpdateExcluded(col ...string) ConflictUpdate
+
+	// DoUpdateAllExcept sets all insert's columns except col and conflict target columns to excluded values,
+	// renders do nothing if all columns are excepted
+	DoUpdateAllExcept(col ...string) ConflictUpdate
+}
+
+type ConflictUpdate interface {
+	// WhereDistinct skips update when values are not changed,
+	// skipped rows are not returned by returning
+	WhereDistinct()
 }
 
 type insertStmt struct {
@@ -66,7 +85,7 @@ func (st *insertStmt) Into(table string) {
 }
 
 func (st *insertStmt) Columns(col ...string) {
-	st.columns.pushString(col...)
+	st.columns.pushIdent(col...)
 }
 
 func (st *insertStmt) OverridingSystemValue() {
@@ -104,6 +123,7 @@ func (st *insertStmt) Select(f func(b SelectStatement)) {
 func (st *insertStmt) OnConflict(f func(b ConflictTarget)) ConflictAction {
 	var x conflict
 	f(&x)
+	x.action.insert = st
 	st.conflict = &x
 	return &st.conflict.action
 }
@@ -129,14 +149,79 @@ func (st *insertStmt) OnConflictOnConstraint(constraintName string) ConflictActi
 }
 
 func (st *insertStmt) Returning(col ...string) {
-	st.returning.pushString(col...)
+	st.returning.pushIdent(col...)
 }
 
 func (st *insertStmt) make() *buffer {
+	var b buffer
+	b.push(&scopeInsert{st})
+	return &b
+}
+
+// scoped returns insert statement with scope column set to scope value,
+// insert must have columns or default values,
+// insert that already has scope column can not be scoped,
+// the given value might be of other scope
+func (st *insertStmt) scoped(col string) (*buffer, error) {
+	if st.columns.empty() && !st.defaultValues {
+		return nil, fmt.Errorf("%w: insert into %s without columns", ErrUnscopable, strings.TrimSpace(st.table))
+	}
+
+	for _, c := range st.columns.q {
+		if c, ok := c.(ident); ok && strings.EqualFold(strings.TrimSpace(c.value), col) {
+			return nil, fmt.Errorf("%w: insert into %s with column %s", ErrUnscopable, strings.TrimSpace(st.table), col)
+		}
+	}
+
+	x := *st
+	if st.conflict != nil {
+		// do not update row of other scope
+		c := *st.conflict
+		c.action.scope = col
+		x.conflict = &c
+	}
+
+	x.columns.group = st.columns.clone()
+	x.columns.push(ident{value: col})
+	if st.defaultValues {
+		x.defaultValues = false
+		x.values = group{}
+		x.values.push(paren(scopeValue{}))
+	}
+	if !st.values.empty() {
+		x.values = st.values.clone()
+		for i, v := range x.values.q {
+			row, ok := v.(*parenGroup)
+			if !ok {
+				continue
+			}
+			p := *row
+			p.group = row.group.clone()
+			p.push(scopeValue{})
+			x.values.q[i] = &p
+		}
+	}
+	if st.selects != nil {
+		x.selects = st.selects.clone()
+		x.selects.columns.push(scopeValue{})
+	}
+	return x.build(), nil
+}
+
+// target returns name to reference target table,
+// target table can be referenced by alias, ex. insert into users as u
+func (st *insertStmt) target() string {
+	if _, alias, ok := parseTable(st.table); ok && alias != "" {
+		return alias
+	}
+	return strings.TrimSpace(st.table)
+}
+
+func (st *insertStmt) build() *buffer {
 	var b buffer
 	b.push("insert")
 	if st.table != "" {
-		b.push("into", st.table)
+		b.push("into", ident{value: st.table, alias: true})
 	}
 	if !st.columns.empty() {
 		b.push(&st.columns)
@@ -180,7 +265,7 @@ func (st *conflict) make() *buffer {
 	b.push("on conflict")
 
 	if len(st.targets) > 0 {
-		b.push(parenString(st.targets...))
+		b.push(parenIdent(st.targets...))
 
 		if !st.where.empty() {
 			b.push("where", &st.where)
@@ -209,6 +294,9 @@ func (st *conflict) OnConstraint(constraintName string) {
 type conflictAction struct {
 	doNothing bool
 	doUpdate  *updateStmt
+	excluded  *conflictUpdate
+	insert    *insertStmt
+	scope     string // scope column, update only row with the same scope value
 }
 
 func (st *conflictAction) make() *buffer {
@@ -217,11 +305,103 @@ func (st *conflictAction) make() *buffer {
 		b.push("do nothing")
 	}
 	if st.doUpdate != nil {
-		b.push("do", st.doUpdate.make())
+		x := st.doUpdate
+		if st.scope != "" {
+			u := *x
+			u.where = x.where.and(ident{value: st.insert.target() + "." + st.scope}, "=", ident{value: "excluded." + st.scope})
+			x = &u
+		}
+		b.push("do", x.make())
+	}
+	if st.excluded != nil {
+		if x := st.excluded.make(st.insert, st.scope); x != nil {
+			b.push("do", x)
+		} else {
+			// all columns are excluded, nothing to update
+			b.push("do nothing")
+		}
 	}
 	return &b
 }
 
+func (st *conflictAction) DoUpdateExcluded(col ...string) ConflictUpdate {
+	st.excluded = &conflictUpdate{columns: col}
+	return st.excluded
+}
+
+func (st *conflictAction) DoUpdateAllExcept(col ...string) ConflictUpdate {
+	st.excluded = &conflictUpdate{columns: col, except: true}
+	return st.excluded
+}
+
+type conflictUpdate struct {
+	columns       []string
+	except        bool
+	whereDistinct bool
+}
+
+func (st *conflictUpdate) WhereDistinct() {
+	st.whereDistinct = true
+}
+
+// make builds update statement from insert's columns,
+// scope is the scope column, empty if not scoped,
+// returns nil if there is no column to update
+func (st *conflictUpdate) make(insert *insertStmt, scope string) *buffer {
+	var targets []string
+	if insert.conflict != nil {
+		// conflict target columns already have the same values
+		targets = insert.conflict.targets
+	}
+
+	cols := st.columns
+	if st.except || len(cols) == 0 {
+		cols = nil
+		for _, c := range insert.columns.q {
+			c, ok := c.(ident)
+			if !ok || containsFold(targets, c.value) {
+				continue
+			}
+			if !st.except || !containsFold(st.columns, c.value) {
+				cols = append(cols, c.value)
+			}
+		}
+	}
+	if len(cols) == 0 {
+		return nil
+	}
+
+	table := insert.target()
+
+	var x updateStmt
+	var current, excluded parenGroup
+	for _, c := range cols {
+		x.Set(c).ToRaw(ident{value: "excluded." + c})
+		current.push(ident{value: table + "." + c})
+		excluded.push(ident{value: "excluded." + c})
+	}
+	if st.whereDistinct {
+		if len(cols) == 1 {
+			x.where.op(current.q[0], "is distinct from", excluded.q[0])
+		} else {
+			x.where.op(&current, "is distinct from", &excluded)
+		}
+	}
+	if scope != "" {
+		x.where = x.where.and(ident{value: table + "." + scope}, "=", ident{value: "excluded." + scope})
+	}
+	return x.make()
+}
+
+func containsFold(xs []string, s string) bool {
+	for _, x := range xs {
+		if strings.EqualFold(strings.TrimSpace(x), strings.TrimSpace(s)) {
+			return true
+		}
+	}
+	return false
+}
+
 func (st *conflictAction) DoNothing() {
 	st.doNothing = true
 }
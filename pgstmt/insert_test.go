@@ -198,4 +198,113 @@ func TestInsert(t *testing.T) {
 			args,
 		)
 	})
+
+	t.Run("insert on conflict do update excluded", func(t *testing.T) {
+		q, args := pgstmt.Insert(func(b pgstmt.InsertStatement) {
+			b.Into("users")
+			b.Columns("id", "name", "email")
+			b.Value(1, "Tester 1", "tester1@localhost")
+			b.OnConflictIndex("id").DoUpdateExcluded("name", "email")
+		}).SQL()
+
+		assert.Equal(t,
+			stripSpace(`
+				insert into users (id, name, email)
+				values ($1, $2, $3)
+				on conflict (id) do update
+				set name = excluded.name,
+					email = excluded.email
+			`),
+			q,
+		)
+		assert.EqualValues(t,
+			[]any{
+				1, "Tester 1", "tester1@localhost",
+			},
+			args,
+		)
+	})
+
+	t.Run("insert on conflict do update all except where distinct", func(t *testing.T) {
+		q, _ := pgstmt.Insert(func(b pgstmt.InsertStatement) {
+			b.OnConflictIndex("id").DoUpdateAllExcept("id").WhereDistinct()
+			b.Into("users as u")
+			b.Columns("id", "name", "email")
+			b.Value(1, "Tester 1", "tester1@localhost")
+			b.Returning("id")
+		}).SQL()
+
+		assert.Equal(t,
+			stripSpace(`
+				insert into users as u (id, name, email)
+				values ($1, $2, $3)
+				on conflict (id) do update
+				set name = excluded.name,
+					email = excluded.email
+				where ((u.name, u.email) is distinct from (excluded.name, excluded.email))
+				returning id
+			`),
+			q,
+		)
+	})
+
+	t.Run("insert on conflict do update all where distinct", func(t *testing.T) {
+		q, _ := pgstmt.Insert(func(b pgstmt.InsertStatement) {
+			b.Into("users")
+			b.Columns("id", "name")
+			b.Value(1, "Tester 1")
+			b.OnConflictIndex("id").DoUpdateExcluded().WhereDistinct()
+		}).SQL()
+
+		assert.Equal(t,
+			stripSpace(`
+				insert into users (id, name)
+				values ($1, $2)
+				on conflict (id) do update
+				set name = excluded.name
+				where (users.name is distinct from excluded.name)
+			`),
+			q,
+		)
+	})
+
+	t.Run("insert on conflict do update all except all columns", func(t *testing.T) {
+		q, _ := pgstmt.Insert(func(b pgstmt.InsertStatement) {
+			b.Into("users")
+			b.Columns("id", "name")
+			b.Value(1, "Tester 1")
+			b.OnConflictIndex("id").DoUpdateAllExcept("name").WhereDistinct()
+		}).SQL()
+
+		assert.Equal(t,
+			stripSpace(`
+				insert into users (id, name)
+				values ($1, $2)
+				on conflict (id) do nothing
+			`),
+			q,
+		)
+	})
+
+	t.Run("insert on conflict do update excluded strict", func(t *testing.T) {
+		r, err := pgstmt.Strict(pgstmt.Insert(func(b pgstmt.InsertStatement) {
+			b.Into("users")
+			b.Columns("id", "name")
+			b.Value(1, "Tester 1")
+			b.OnConflictIndex("id").DoUpdateExcluded("name").WhereDistinct()
+		}))
+		assert.NoError(t, err)
+
+		q, _ := r.SQL()
+		assert.Equal(t,
+			stripSpace(`
+				insert into "users" ("id", "name")
+				values ($1, $2)
+				on conflict ("id") do update
+				set "name" = "excluded"."name"
+				where ("users"."name" is distinct from "excluded"."name")
+			`),
+			q,
+		)
+	})
 }
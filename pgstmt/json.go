@@ -0,0 +1,100 @@
+package pgstmt
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/acoshift/pgsql"
+)
+
+// JSONPath builds json path expression returns json,
+// renders col->'key' for single key, and col#>'{key1,key2}' for multiple keys.
+//
+// Key can be string for object field or int for array index.
+func JSONPath(col any, keys ...any) any {
+	return &jsonPath{col: col, keys: keys}
+}
+
+// JSONPathText builds json path expression returns text,
+// renders col->>'key' for single key, and col#>>'{key1,key2}' for multiple keys.
+func JSONPathText(col any, keys ...any) any {
+	return &jsonPath{col: col, keys: keys, text: true}
+}
+
+type jsonPath struct {
+	col  any
+	keys []any
+	text bool
+}
+
+func (p *jsonPath) build() []any {
+	var x group
+	x.concat = true
+	x.push(p.col)
+
+	switch len(p.keys) {
+	case 0:
+	case 1:
+		if p.text {
+			x.push("->>")
+		} else {
+			x.push("->")
+		}
+		switch k := p.keys[0].(type) {
+		case int:
+			x.push(strconv.Itoa(k))
+		default:
+			x.push(pq.QuoteLiteral(convertToString(k, false)))
+		}
+	default:
+		if p.text {
+			x.push("#>>")
+		} else {
+			x.push("#>")
+		}
+		ks := make([]string, len(p.keys))
+		for i, k := range p.keys {
+			s := convertToString(k, false)
+			s = strings.ReplaceAll(s, `\`, `\\`)
+			s = strings.ReplaceAll(s, `"`, `\"`)
+			ks[i] = `"` + s + `"`
+		}
+		x.push(pq.QuoteLiteral("{" + strings.Join(ks, ",") + "}"))
+	}
+	return []any{&x}
+}
+
+// jsonb casts json value argument to jsonb
+func jsonb(value any) any {
+	return concat(Arg(pgsql.JSON(value)), "::jsonb")
+}
+
+func (st *cond) JSONContains(field, value any) {
+	st.op(field, "@>", jsonb(value))
+}
+
+func (st *cond) JSONContainedBy(field, value any) {
+	st.op(field, "<@", jsonb(value))
+}
+
+func (st *cond) JSONHasKey(field any, key string) {
+	st.Op(field, "?", key)
+}
+
+func (st *cond) JSONHasAnyKeys(field any, keys ...string) {
+	st.Op(field, "?|", pq.Array(keys))
+}
+
+func (st *cond) JSONHasAllKeys(field any, keys ...string) {
+	st.Op(field, "?&", pq.Array(keys))
+}
+
+func (st *cond) JSONPathExists(field any, path string) {
+	st.op(field, "@?", concat(Arg(path), "::jsonpath"))
+}
+
+func (st *cond) JSONPathMatch(field any, path string) {
+	st.op(field, "@@", concat(Arg(path), "::jsonpath"))
+}
@@ -0,0 +1,61 @@
+package pgstmt_test
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql"
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	q, args := pgstmt.Select(func(b pgstmt.SelectStatement) {
+		b.Columns(
+			pgstmt.JSONPath("data", "a"),
+			pgstmt.JSONPathText("data", "a"),
+			pgstmt.JSONPath("data", "items", 0),
+			pgstmt.JSONPath("data", 0),
+			pgstmt.JSONPath("data", "a", "b"),
+			pgstmt.JSONPathText("data", "a", `x"y`, 1),
+			pgstmt.JSONPathText("data", "it's"),
+		)
+		b.From("events")
+		b.Where(func(b pgstmt.Cond) {
+			b.Eq(pgstmt.JSONPathText("data", "type"), "click")
+			b.JSONContains("data", map[string]any{"a": 1})
+			b.JSONContainedBy("tags", []string{"x"})
+			b.JSONHasKey("data", "k")
+			b.JSONHasAnyKeys("data", "a", "b")
+			b.JSONHasAllKeys("data", "c")
+			b.JSONPathExists("data", "$.a ? (@ > 1)")
+			b.JSONPathMatch("data", "$.a == 1")
+		})
+	}).SQL()
+
+	assert.Equal(t, stripSpace(`
+		select data->'a', data->>'a', data#>'{"items","0"}', data->0, data#>'{"a","b"}', data#>> E'{"a","x\\"y","1"}', data->>'it''s'
+		from events
+		where (data->>'type' = $1
+			and data @> $2::jsonb
+			and tags <@ $3::jsonb
+			and data ? $4
+			and data ?| $5
+			and data ?& $6
+			and data @? $7::jsonpath
+			and data @@ $8::jsonpath)
+	`), q)
+	assert.EqualValues(t, []any{
+		"click",
+		pgsql.JSON(map[string]any{"a": 1}),
+		pgsql.JSON([]string{"x"}),
+		"k",
+		pq.Array([]string{"a", "b"}),
+		pq.Array([]string{"c"}),
+		"$.a ? (@ > 1)",
+		"$.a == 1",
+	}, args)
+}
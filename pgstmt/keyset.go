@@ -0,0 +1,262 @@
+package pgstmt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidCursor is the error when keyset cursor can not be decoded
+var ErrInvalidCursor = errors.New("pgstmt: invalid cursor")
+
+const defaultKeysetLimit = 20
+
+// Keyset is the keyset (cursor) pagination.
+//
+// Keyset orders rows by columns, columns should be unique together
+// (ex. add primary key as the last column).
+type Keyset struct {
+	Limit  int64  // page size, default to 20
+	Cursor string // cursor from KeysetPage, empty for first page
+
+	columns []*orderBy
+}
+
+// KeysetStatement is the statement that can be paginated by Keyset
+type KeysetStatement interface {
+	Where(f func(b Cond))
+	OrderBy(col string) OrderBy
+	Limit(n int64)
+}
+
+// KeysetPage is the cursors of paginated result
+type KeysetPage struct {
+	Next string // cursor for next page, empty if no next page
+	Prev string // cursor for previous page, empty if no previous page
+}
+
+type keysetCursor struct {
+	Prev   bool  `json:"p,omitempty"`
+	Values []any `json:"v"`
+}
+
+// OrderBy adds ordered column
+func (k *Keyset) OrderBy(col string) OrderBy {
+	p := orderBy{
+		col: col,
+	}
+	k.columns = append(k.columns, &p)
+	return &p
+}
+
+func (k *Keyset) limit() int64 {
+	if k.Limit <= 0 {
+		return defaultKeysetLimit
+	}
+	return k.Limit
+}
+
+func (k *Keyset) decode() (*keysetCursor, error) {
+	if k.Cursor == "" {
+		return nil, nil
+	}
+
+	p, err := base64.RawURLEncoding.DecodeString(k.Cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c keysetCursor
+	dec := json.NewDecoder(strings.NewReader(string(p)))
+	dec.UseNumber()
+	if err := dec.Decode(&c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if len(c.Values) != len(k.columns) {
+		return nil, ErrInvalidCursor
+	}
+	for i, v := range c.Values {
+		switch v := v.(type) {
+		case nil, bool, string:
+		case json.Number:
+			// send as text, database will infer type from column
+			c.Values[i] = string(v)
+		default:
+			return nil, ErrInvalidCursor
+		}
+	}
+	return &c, nil
+}
+
+func encodeKeysetCursor(prev bool, values []any) (string, error) {
+	p, err := json.Marshal(keysetCursor{Prev: prev, Values: values})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(p), nil
+}
+
+// Apply applies cursor condition, order by and limit into statement,
+// limit will be Limit+1 to detect next page.
+func (k *Keyset) Apply(b KeysetStatement) error {
+	c, err := k.decode()
+	if err != nil {
+		return err
+	}
+	backward := c != nil && c.Prev
+
+	type column struct {
+		name       string
+		col        string // escaped for Expr
+		asc        bool
+		nullsFirst bool
+	}
+
+	columns := make([]column, len(k.columns))
+	for i, x := range k.columns {
+		asc := x.direction != "desc"
+		nullsFirst := !asc // postgres default
+		if x.nulls != "" {
+			nullsFirst = x.nulls == "first"
+		}
+		if backward {
+			asc = !asc
+			nullsFirst = !nullsFirst
+		}
+		columns[i] = column{
+			name:       convertToString(x.col, false),
+			col:        strings.ReplaceAll(convertToString(x.col, false), "?", "??"),
+			asc:        asc,
+			nullsFirst: nullsFirst,
+		}
+	}
+
+	if c != nil {
+		var (
+			ors    []string
+			args   []any
+			eqs    []string
+			eqArgs []any
+		)
+		for i, x := range columns {
+			v := c.Values[i]
+
+			// after is the condition for rows after cursor on this column
+			var after string
+			var afterArgs []any
+			switch {
+			case v == nil && x.nullsFirst:
+				after = x.col + " is not null"
+			case v == nil:
+				// nothing after null when nulls last
+			default:
+				op := ">"
+				if !x.asc {
+					op = "<"
+				}
+				after = x.col + " " + op + " ?"
+				afterArgs = []any{v}
+				if !x.nullsFirst {
+					after = "(" + after + " or " + x.col + " is null)"
+				}
+			}
+
+			if after != "" {
+				if len(eqs) > 0 {
+					after = "(" + strings.Join(eqs, " and ") + " and " + after + ")"
+				}
+				ors = append(ors, after)
+				args = append(append(args, eqArgs...), afterArgs...)
+			}
+
+			if v == nil {
+				eqs = append(eqs, x.col+" is null")
+			} else {
+				eqs = append(eqs, x.col+" = ?")
+				eqArgs = append(eqArgs, v)
+			}
+		}
+
+		pred := func(b Cond) {
+			if len(ors) == 0 {
+				b.Raw("false")
+				return
+			}
+			b.Expr("("+strings.Join(ors, " or ")+")", args...)
+		}
+		b.Where(func(b Cond) {
+			// cursor must be and with caller's conditions, even in or mode
+			if c, ok := b.(*cond); ok {
+				var x cond
+				pred(&x)
+				*c = c.andOp(x.ops.q[0])
+				return
+			}
+			b.And(pred)
+		})
+	}
+
+	for _, x := range columns {
+		o := b.OrderBy(x.name)
+		if x.asc {
+			o.Asc()
+		} else {
+			o.Desc()
+		}
+		if x.nullsFirst == x.asc { // not postgres default
+			if x.nullsFirst {
+				o.NullsFirst()
+			} else {
+				o.NullsLast()
+			}
+		}
+	}
+
+	b.Limit(k.limit() + 1)
+	return nil
+}
+
+// KeysetItems returns items of current page and cursors,
+// items must be the result from statement applied by k,
+// key returns values of ordered columns for given item.
+func KeysetItems[T any](k *Keyset, items []T, key func(item T) []any) ([]T, KeysetPage, error) {
+	var page KeysetPage
+
+	c, err := k.decode()
+	if err != nil {
+		return nil, page, err
+	}
+	backward := c != nil && c.Prev
+
+	hasMore := int64(len(items)) > k.limit()
+	if hasMore {
+		items = items[:k.limit()]
+	}
+
+	hasNext, hasPrev := hasMore, c != nil
+	if backward {
+		// rows fetched in reverse order
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+		hasNext, hasPrev = true, hasMore
+	}
+
+	if len(items) == 0 {
+		return items, page, nil
+	}
+	if hasNext {
+		page.Next, err = encodeKeysetCursor(false, key(items[len(items)-1]))
+		if err != nil {
+			return nil, page, err
+		}
+	}
+	if hasPrev {
+		page.Prev, err = encodeKeysetCursor(true, key(items[0]))
+		if err != nil {
+			return nil, page, err
+		}
+	}
+	return items, page, nil
+}
@@ -0,0 +1,139 @@
+package pgstmt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestKeyset(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		ID    int64
+		Score *int64
+	}
+	key := func(x item) []any {
+		return []any{x.Score, x.ID}
+	}
+	score := func(v int64) *int64 { return &v }
+
+	newKeyset := func(cursor string) *pgstmt.Keyset {
+		k := pgstmt.Keyset{Limit: 2, Cursor: cursor}
+		k.OrderBy("score").Desc().NullsLast()
+		k.OrderBy("id")
+		return &k
+	}
+	build := func(k *pgstmt.Keyset) (string, []any) {
+		var err error
+		q, args := pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("id", "score")
+			b.From("users")
+			b.Where(func(b pgstmt.Cond) {
+				b.Eq("status", "active")
+			})
+			err = k.Apply(b)
+		}).SQL()
+		assert.NoError(t, err)
+		return q, args
+	}
+
+	// first page
+	k := newKeyset("")
+	q, args := build(k)
+	assert.Equal(t, "select id, score from users where (status = $1) order by score desc nulls last, id asc limit 3", q)
+	assert.EqualValues(t, []any{"active"}, args)
+
+	items, page, err := pgstmt.KeysetItems(k, []item{{1, score(10)}, {2, score(5)}, {3, nil}}, key)
+	assert.NoError(t, err)
+	assert.Equal(t, []item{{1, score(10)}, {2, score(5)}}, items)
+	assert.NotEmpty(t, page.Next)
+	assert.Empty(t, page.Prev)
+
+	// second page
+	k = newKeyset(page.Next)
+	q, args = build(k)
+	assert.Equal(t, stripSpace(`
+		select id, score from users
+		where (status = $1 and ((score < $2 or score is null) or (score = $3 and (id > $4 or id is null))))
+		order by score desc nulls last, id asc
+		limit 3
+	`), q)
+	assert.EqualValues(t, []any{"active", "5", "5", "2"}, args)
+
+	items, page, err = pgstmt.KeysetItems(k, []item{{3, nil}, {4, nil}}, key)
+	assert.NoError(t, err)
+	assert.Equal(t, []item{{3, nil}, {4, nil}}, items)
+	assert.Empty(t, page.Next)
+	assert.NotEmpty(t, page.Prev)
+
+	// back to previous page, cursor at null score
+	k = newKeyset(page.Prev)
+	q, args = build(k)
+	assert.Equal(t, stripSpace(`
+		select id, score from users
+		where (status = $1 and (score is not null or (score is null and id < $2)))
+		order by score asc nulls first, id desc
+		limit 3
+	`), q)
+	assert.EqualValues(t, []any{"active", "3"}, args)
+
+	items, page, err = pgstmt.KeysetItems(k, []item{{2, score(5)}, {1, score(10)}}, key)
+	assert.NoError(t, err)
+	assert.Equal(t, []item{{1, score(10)}, {2, score(5)}}, items)
+	assert.NotEmpty(t, page.Next)
+	assert.Empty(t, page.Prev)
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		var err error
+		pgstmt.Select(func(b pgstmt.SelectStatement) {
+			err = newKeyset("invalid").Apply(b)
+		})
+		assert.ErrorIs(t, err, pgstmt.ErrInvalidCursor)
+	})
+
+	t.Run("or conditions", func(t *testing.T) {
+		_, page, err := pgstmt.KeysetItems(newKeyset(""), []item{{1, score(10)}, {2, score(5)}, {3, nil}}, key)
+		assert.NoError(t, err)
+
+		q, args := pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("id", "score")
+			b.From("users")
+			b.Where(func(b pgstmt.Cond) {
+				b.Mode().Or()
+				b.Eq("status", "active")
+				b.Eq("role", "admin")
+			})
+			err = newKeyset(page.Next).Apply(b)
+		}).SQL()
+		assert.NoError(t, err)
+		assert.Equal(t, stripSpace(`
+			select id, score from users
+			where ((status = $1 or role = $2) and ((score < $3 or score is null) or (score = $4 and (id > $5 or id is null))))
+			order by score desc nulls last, id asc
+			limit 3
+		`), q)
+		assert.EqualValues(t, []any{"active", "admin", "5", "5", "2"}, args)
+
+		q, _ = pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("id", "score")
+			b.From("users")
+			b.Where(func(b pgstmt.Cond) {
+				b.Eq("status", "active")
+				b.Or(func(b pgstmt.Cond) {
+					b.Eq("role", "admin")
+				})
+			})
+			err = newKeyset(page.Next).Apply(b)
+		}).SQL()
+		assert.NoError(t, err)
+		assert.Equal(t, stripSpace(`
+			select id, score from users
+			where (((status = $1) or (role = $2)) and ((score < $3 or score is null) or (score = $4 and (id > $5 or id is null))))
+			order by score desc nulls last, id asc
+			limit 3
+		`), q)
+	})
+}
@@ -0,0 +1,94 @@
+package pgstmt
+
+// Fetch is the fetch first clause builder
+type Fetch interface {
+	// WithTies includes rows that tie with the last row, requires order by
+	WithTies()
+}
+
+type fetch struct {
+	n        any
+	withTies bool
+}
+
+func (st *fetch) WithTies() {
+	st.withTies = true
+}
+
+func (st *fetch) build() []any {
+	var b buffer
+	b.push("fetch first", st.n, "rows")
+	if st.withTies {
+		b.push("with ties")
+	} else {
+		b.push("only")
+	}
+	return b.q
+}
+
+// limitOffset is the limit, offset and fetch first clauses,
+// limit and fetch first can not be used together, the last one set is used
+type limitOffset struct {
+	limit  any
+	offset any
+	fetch  *fetch
+}
+
+func (st *limitOffset) Limit(n int64) {
+	st.limit = n
+	st.fetch = nil
+}
+
+func (st *limitOffset) LimitArg(n int64) {
+	st.limit = Arg(n)
+	st.fetch = nil
+}
+
+func (st *limitOffset) LimitAll() {
+	st.limit = "all"
+	st.fetch = nil
+}
+
+func (st *limitOffset) Offset(n int64) {
+	st.offset = n
+}
+
+func (st *limitOffset) OffsetArg(n int64) {
+	st.offset = Arg(n)
+}
+
+func (st *limitOffset) FetchFirst(n int64) Fetch {
+	st.limit = nil
+	st.fetch = &fetch{n: n}
+	return st.fetch
+}
+
+func (st *limitOffset) FetchFirstArg(n int64) Fetch {
+	st.limit = nil
+	st.fetch = &fetch{n: Arg(n)}
+	return st.fetch
+}
+
+func (st *limitOffset) make(b *buffer) {
+	if st.limit != nil {
+		b.push("limit", st.limit)
+	}
+	if st.offset != nil {
+		b.push("offset", st.offset)
+	}
+	if st.fetch != nil {
+		b.push(st.fetch)
+	}
+}
+
+// int64Value returns value of limit or offset
+func int64Value(v any) (int64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return v, true
+	case arg:
+		n, ok := v.value.(int64)
+		return n, ok
+	}
+	return 0, false
+}
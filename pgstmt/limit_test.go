@@ -0,0 +1,164 @@
+package pgstmt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestLimit(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		result *pgstmt.Result
+		query  string
+		args   []any
+	}{
+		{
+			"bound limit offset",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("id")
+				b.From("users")
+				b.Where(func(b pgstmt.Cond) {
+					b.Eq("status", "active")
+				})
+				b.OrderBy("id")
+				b.LimitArg(10)
+				b.OffsetArg(20)
+			}),
+			`
+				select id
+				from users
+				where (status = $1)
+				order by id
+				limit $2 offset $3
+			`,
+			[]any{
+				"active",
+				int64(10),
+				int64(20),
+			},
+		},
+		{
+			"limit all",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("id")
+				b.From("users")
+				b.LimitAll()
+				b.Offset(5)
+			}),
+			`
+				select id
+				from users
+				limit all offset 5
+			`,
+			nil,
+		},
+		{
+			"fetch first only",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("id")
+				b.From("users")
+				b.OrderBy("id")
+				b.Offset(10)
+				b.FetchFirst(5)
+			}),
+			`
+				select id
+				from users
+				order by id
+				offset 10
+				fetch first 5 rows only
+			`,
+			nil,
+		},
+		{
+			"fetch first with ties",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("id", "score")
+				b.From("users")
+				b.OrderBy("score").Desc()
+				b.FetchFirstArg(3).WithTies()
+			}),
+			`
+				select id, score
+				from users
+				order by score desc
+				fetch first $1 rows with ties
+			`,
+			[]any{
+				int64(3),
+			},
+		},
+		{
+			"union",
+			pgstmt.Union(func(b pgstmt.UnionStatement) {
+				b.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("id")
+					b.From("t1")
+				})
+				b.AllSelect(func(b pgstmt.SelectStatement) {
+					b.Columns("id")
+					b.From("t2")
+				})
+				b.OrderBy("id")
+				b.OffsetArg(10)
+				b.FetchFirstArg(5).WithTies()
+			}),
+			`
+				(select id from t1)
+				union all (select id from t2)
+				order by id
+				offset $1
+				fetch first $2 rows with ties
+			`,
+			[]any{
+				int64(10),
+				int64(5),
+			},
+		},
+		{
+			"fetch first replaces limit",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("id")
+				b.From("users")
+				b.Limit(10)
+				b.FetchFirst(5)
+			}),
+			"select id from users fetch first 5 rows only",
+			nil,
+		},
+		{
+			"limit replaces fetch first",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("id")
+				b.From("users")
+				b.FetchFirstArg(5).WithTies()
+				b.LimitArg(10)
+			}),
+			"select id from users limit $1",
+			[]any{int64(10)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, args := tc.result.SQL()
+			assert.Equal(t, stripSpace(tc.query), q)
+			assert.EqualValues(t, tc.args, args)
+		})
+	}
+
+	t.Run("format", func(t *testing.T) {
+		q, _ := pgstmt.Format(pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("id")
+			b.From("users")
+			b.OffsetArg(10)
+			b.FetchFirst(5).WithTies()
+		})).SQL()
+		assert.Equal(t, "select id\nfrom users\noffset $1\nfetch first 5 rows with ties", q)
+	})
+}
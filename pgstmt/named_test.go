@@ -0,0 +1,81 @@
+package pgstmt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgctx"
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestNamed(t *testing.T) {
+	t.Parallel()
+
+	r := pgstmt.Select(func(b pgstmt.SelectStatement) {
+		b.Columns("*")
+		b.From("users")
+		b.Where(func(b pgstmt.Cond) {
+			b.Eq("owner_id", pgstmt.Named("user_id"))
+			b.Eq("status", "active")
+			b.Or(func(b pgstmt.Cond) {
+				b.Eq("created_by", pgstmt.Named("user_id"))
+				b.Eq("id", pgstmt.Any(pgstmt.Named("ids")))
+			})
+		})
+	})
+
+	q, args := r.SQL()
+	assert.Equal(t, "select * from users where (owner_id = $1 and status = $2) or (created_by = $1 and id = any($3))", q)
+	assert.Len(t, args, 3)
+	assert.Equal(t, "select * from users where (owner_id = :user_id and status = 'active') or (created_by = :user_id and id = any(:ids))", r.Interpolate())
+
+	t.Run("bind map", func(t *testing.T) {
+		b, err := r.Bind(map[string]any{
+			"user_id": 7,
+			"ids":     []int64{1, 2},
+		})
+		if assert.NoError(t, err) {
+			q, args := b.SQL()
+			assert.Equal(t, "select * from users where (owner_id = $1 and status = $2) or (created_by = $1 and id = any($3))", q)
+			assert.Equal(t, []any{7, "active", []int64{1, 2}}, args)
+		}
+	})
+
+	t.Run("bind struct", func(t *testing.T) {
+		b, err := r.Bind(struct {
+			UserID int     `db:"user_id"`
+			IDs    []int64 `db:"ids"`
+		}{7, nil})
+		if assert.NoError(t, err) {
+			_, args := b.SQL()
+			assert.Equal(t, []any{7, "active", []int64(nil)}, args)
+
+			_, args = pgstmt.Format(b).SQL()
+			assert.Equal(t, []any{7, "active", []int64(nil)}, args)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := r.Bind(map[string]any{"user_id": 1})
+		assert.Error(t, err)
+	})
+
+	t.Run("not bound", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		ctx := pgctx.NewContext(context.Background(), db)
+
+		assert.NoError(t, r.Err())
+		_, err = r.QueryWith(ctx)
+		assert.EqualError(t, err, `pgstmt: named argument "user_id" not bound`)
+		_, err = r.ExecContext(ctx, db.ExecContext)
+		assert.Error(t, err)
+		assert.Error(t, r.QueryRow(db.QueryRow).Scan(new(int)))
+		_, _, err = r.SQLContext(ctx)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -3,29 +3,96 @@ package pgstmt
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"fmt"
 
 	"github.com/acoshift/pgsql"
 	"github.com/acoshift/pgsql/pgctx"
 )
 
 type Result struct {
-	query string
-	args  []any
+	query  string
+	args   []any
+	b      *buffer
+	pretty bool
+	strict bool
+	scope  *Scope
+	err    error
 }
 
-func newResult(query string, args []any) *Result {
-	return &Result{query, args}
+func newResult(b *buffer) *Result {
+	query, args, err := build(b)
+	return &Result{query: query, args: args, b: b, err: err}
 }
 
+// SQL returns query and arguments,
+// query is invalid if Err returns an error
 func (r *Result) SQL() (query string, args []any) {
 	return r.query, r.args
 }
 
+// Err returns error occurred while building the statement,
+// Query, QueryRow and Exec methods return this error without sending query to database
+func (r *Result) Err() error {
+	return r.err
+}
+
+// sqlErr returns error that prevents sending query to database,
+// build error or named argument not bound
+func (r *Result) sqlErr() error {
+	if r.err != nil {
+		return r.err
+	}
+	for _, x := range r.args {
+		if x, ok := x.(named); ok {
+			return fmt.Errorf("pgstmt: named argument %q not bound", x.name)
+		}
+	}
+	return nil
+}
+
+// Bind returns new result with named arguments replaced by values from arg.
+//
+// see pgsql.BindNamed for supported arg types.
+func (r *Result) Bind(arg any) (*Result, error) {
+	var names []string
+	var pos []int
+	for i, x := range r.args {
+		if x, ok := x.(named); ok {
+			names = append(names, x.name)
+			pos = append(pos, i)
+		}
+	}
+	if len(names) == 0 {
+		return r, nil
+	}
+
+	values, err := pgsql.BindNamed(names, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]any, len(r.args))
+	copy(args, r.args)
+	for i, p := range pos {
+		args[p] = values[i]
+	}
+	x := *r
+	x.args = args
+	return &x, nil
+}
+
 func (r *Result) QueryRow(f func(string, ...any) *sql.Row) *pgsql.Row {
+	if err := r.sqlErr(); err != nil {
+		return errorRow(err)
+	}
 	return &pgsql.Row{f(r.query, r.args...)}
 }
 
 func (r *Result) Query(f func(string, ...any) (*sql.Rows, error)) (*pgsql.Rows, error) {
+	if err := r.sqlErr(); err != nil {
+		return nil, err
+	}
 	rows, err := f(r.query, r.args...)
 	if err != nil {
 		return nil, err
@@ -34,14 +101,25 @@ func (r *Result) Query(f func(string, ...any) (*sql.Rows, error)) (*pgsql.Rows,
 }
 
 func (r *Result) Exec(f func(string, ...any) (sql.Result, error)) (sql.Result, error) {
+	if err := r.sqlErr(); err != nil {
+		return nil, err
+	}
 	return f(r.query, r.args...)
 }
 
 func (r *Result) QueryRowContext(ctx context.Context, f func(context.Context, string, ...any) *sql.Row) *pgsql.Row {
+	r = r.withContext(ctx)
+	if err := r.sqlErr(); err != nil {
+		return errorRow(err)
+	}
 	return &pgsql.Row{f(ctx, r.query, r.args...)}
 }
 
 func (r *Result) QueryContext(ctx context.Context, f func(context.Context, string, ...any) (*sql.Rows, error)) (*pgsql.Rows, error) {
+	r = r.withContext(ctx)
+	if err := r.sqlErr(); err != nil {
+		return nil, err
+	}
 	rows, err := f(ctx, r.query, r.args...)
 	if err != nil {
 		return nil, err
@@ -50,21 +128,69 @@ func (r *Result) QueryContext(ctx context.Context, f func(context.Context, strin
 }
 
 func (r *Result) ExecContext(ctx context.Context, f func(context.Context, string, ...any) (sql.Result, error)) (sql.Result, error) {
+	r = r.withContext(ctx)
+	if err := r.sqlErr(); err != nil {
+		return nil, err
+	}
 	return f(ctx, r.query, r.args...)
 }
 
 func (r *Result) QueryRowWith(ctx context.Context) *pgsql.Row {
+	r = r.withContext(ctx)
+	if err := r.sqlErr(); err != nil {
+		return errorRow(err)
+	}
 	return pgctx.QueryRow(ctx, r.query, r.args...)
 }
 
 func (r *Result) QueryWith(ctx context.Context) (*pgsql.Rows, error) {
+	r = r.withContext(ctx)
+	if err := r.sqlErr(); err != nil {
+		return nil, err
+	}
 	return pgctx.Query(ctx, r.query, r.args...)
 }
 
 func (r *Result) ExecWith(ctx context.Context) (sql.Result, error) {
+	r = r.withContext(ctx)
+	if err := r.sqlErr(); err != nil {
+		return nil, err
+	}
 	return pgctx.Exec(ctx, r.query, r.args...)
 }
 
 func (r *Result) IterWith(ctx context.Context, iter pgsql.Iterator) error {
+	r = r.withContext(ctx)
+	if err := r.sqlErr(); err != nil {
+		return err
+	}
 	return pgctx.Iter(ctx, iter, r.query, r.args...)
 }
+
+// errorRow returns row that returns err when scan,
+// for query that failed before sending to database
+func errorRow(err error) *pgsql.Row {
+	// sql.Row can not be created outside database/sql,
+	// query row on db that fails to connect returns row with the error
+	db := sql.OpenDB(errConnector{err})
+	defer db.Close()
+	return &pgsql.Row{Row: db.QueryRow("")}
+}
+
+type errConnector struct {
+	err error
+}
+
+func (c errConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, c.err
+}
+
+func (c errConnector) Driver() driver.Driver {
+	return errDriver(c)
+}
+
+type errDriver errConnector
+
+func (d errDriver) Open(string) (driver.Conn, error) {
+	return nil, d.err
+}
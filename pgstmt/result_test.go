@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/acoshift/pgsql"
@@ -52,3 +53,24 @@ func TestResult_QueryAllWith(t *testing.T) {
 		}, vs)
 	}
 }
+
+func TestResult_QueryRowErr(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	ctx := pgctx.NewContext(context.Background(), db)
+
+	r := pgstmt.Select(func(b pgstmt.SelectStatement) {
+		b.Columns(pgstmt.Expr("?"))
+	})
+	assert.Error(t, r.Err())
+
+	var x int
+	row := r.QueryRowWith(ctx)
+	assert.Equal(t, r.Err(), row.Err())
+	assert.Equal(t, r.Err(), row.Scan(&x))
+	assert.Equal(t, r.Err(), r.QueryRow(db.QueryRow).Scan(&x))
+	assert.Equal(t, r.Err(), r.QueryRowContext(ctx, db.QueryRowContext).Scan(&x))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,48 @@
+package pgstmt
+
+// CreateSchema builds create schema statement
+func CreateSchema(f func(b CreateSchemaStatement)) *Result {
+	var st createSchemaStmt
+	f(&st)
+	return newResult(newDDL(st.make()))
+}
+
+// CreateSchemaStatement is the create schema statement builder
+type CreateSchemaStatement interface {
+	Schema(name string)
+	IfNotExists()
+	Authorization(role string)
+}
+
+type createSchemaStmt struct {
+	name          string
+	ifNotExists   bool
+	authorization string
+}
+
+func (st *createSchemaStmt) Schema(name string) {
+	st.name = name
+}
+
+func (st *createSchemaStmt) IfNotExists() {
+	st.ifNotExists = true
+}
+
+func (st *createSchemaStmt) Authorization(role string) {
+	st.authorization = role
+}
+
+func (st *createSchemaStmt) make() *buffer {
+	var b buffer
+	b.push("create schema")
+	if st.ifNotExists {
+		b.push("if not exists")
+	}
+	if st.name != "" {
+		b.push(ident{value: st.name})
+	}
+	if st.authorization != "" {
+		b.push("authorization", ident{value: st.authorization})
+	}
+	return &b
+}
@@ -0,0 +1,307 @@
+package pgstmt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrScopeRequired is the error when statement uses table required by RequireScope
+	// without scope covering the table
+	ErrScopeRequired = errors.New("pgstmt: scope required")
+
+	// ErrUnscopable is the error when scoped table is used in statement that can not be scoped,
+	// ex. truncate, insert without columns
+	ErrUnscopable = errors.New("pgstmt: statement can not be scoped")
+)
+
+// Scope is the row-scoping rule (ex. multi-tenant),
+// scoped statements filter rows by the column in select, update and delete,
+// and set the column in insert with columns or default values.
+//
+// Insert that already has the column can not be scoped (use Unscoped context to set the column),
+// insert on conflict do update only updates row with the same column value.
+//
+// Statement on scoped table that can not be scoped returns ErrUnscopable,
+// table string must be a single table with optional alias (ex. "users u"),
+// statement with table string that can not be parsed (ex. "users, orders") returns ErrUnscopable.
+type Scope struct {
+	Column string   // ex. tenant_id
+	Value  any      // ex. current tenant id
+	Tables []string // scoped tables, table without schema matches any schema
+}
+
+type ctxKeyScope struct{}
+
+// NewScopeContext creates new context with scope,
+// Result's *With methods apply the scope to statement
+func NewScopeContext(ctx context.Context, s *Scope) context.Context {
+	return context.WithValue(ctx, ctxKeyScope{}, s)
+}
+
+// Unscoped creates new context without scope, ex. for admin queries
+func Unscoped(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeyScope{}, (*Scope)(nil))
+}
+
+type ctxKeyRequireScope struct{}
+
+// RequireScope creates new context that requires tables to be scoped,
+// Result's *With methods return ErrScopeRequired for statement using the tables
+// without scope covering the table.
+//
+// Use Unscoped context to run the statement without scope.
+func RequireScope(ctx context.Context, table ...string) context.Context {
+	required, _ := ctx.Value(ctxKeyRequireScope{}).([]string)
+	required = append(required[:len(required):len(required)], table...)
+	return context.WithValue(ctx, ctxKeyRequireScope{}, required)
+}
+
+// match returns qualifier of table if table is scoped
+func (s *Scope) match(table ident) (string, bool) {
+	return matchTable(s.Tables, table)
+}
+
+// matchTable returns qualifier of table if table is in tables
+func matchTable(tables []string, table ident) (string, bool) {
+	name, alias, ok := parseTable(table.value)
+	if !ok {
+		return "", false
+	}
+	for _, t := range tables {
+		x, _, ok := parseTable(t)
+		if !ok {
+			continue
+		}
+		if x == name || (!strings.Contains(x, ".") && strings.HasSuffix(name, "."+x)) {
+			if alias != "" {
+				return alias, true
+			}
+			return strings.TrimSpace(table.value), true
+		}
+	}
+	return "", false
+}
+
+// parseTable parses table name, returns quoted name and alias,
+// alias must be a single identifier, optionally after as,
+// ex. "users", "public.users u", "users as u"
+func parseTable(s string) (name, alias string, ok bool) {
+	name, rest, err := scanIdentChain(strings.TrimSpace(s))
+	if err != nil || strings.HasSuffix(name, "*") {
+		return "", "", false
+	}
+	if rest == "" {
+		return name, "", true
+	}
+
+	alias = strings.TrimLeft(rest, " \t\r\n")
+	if alias == rest {
+		// not separated by space, ex. "users, orders"
+		return "", "", false
+	}
+	if p := strings.Fields(alias); len(p) == 2 && strings.EqualFold(p[0], "as") {
+		alias = p[1]
+	}
+	if strings.EqualFold(alias, "as") {
+		return "", "", false
+	}
+	if _, tail, err := scanIdent(alias); err != nil || tail != "" {
+		return "", "", false
+	}
+	return name, alias, true
+}
+
+// Scope returns new result with scope applied, nil scope removes the scope
+func (r *Result) Scope(s *Scope) *Result {
+	return r.rescope(s, nil)
+}
+
+// rescope returns new result with scope applied,
+// records ErrScopeRequired if statement uses required table without scope
+func (r *Result) rescope(s *Scope, required []string) *Result {
+	if r.b == nil {
+		return r
+	}
+
+	args := r.args
+	if r.scope != nil {
+		args = args[:len(args)-1]
+	}
+
+	x := renderer{pretty: r.pretty, strict: r.strict, scope: s, required: required, scopeArg: len(args) + 1}
+	query := x.renderResult(r.b)
+	if x.scoped {
+		args = append(append([]any(nil), args...), s.Value)
+	} else {
+		s = nil
+	}
+	y := *r
+	y.query = query
+	y.args = args
+	y.scope = s
+	y.err = x.error()
+	return &y
+}
+
+// withContext returns result with scope from context,
+// result with scope already applied keeps its scope,
+// unscoped context allows statement on tables required by RequireScope
+func (r *Result) withContext(ctx context.Context) *Result {
+	s, ok := ctx.Value(ctxKeyScope{}).(*Scope)
+	if ok && s == nil {
+		return r
+	}
+	if r.scope != nil {
+		s = r.scope
+	}
+	required, _ := ctx.Value(ctxKeyRequireScope{}).([]string)
+	if len(required) == 0 && (s == nil || s == r.scope) {
+		return r
+	}
+	return r.rescope(s, required)
+}
+
+// SQLContext returns query and arguments with scope from context applied (same as *With methods),
+// and error occurred while building the statement or named argument not bound
+func (r *Result) SQLContext(ctx context.Context) (query string, args []any, err error) {
+	r = r.withContext(ctx)
+	return r.query, r.args, r.sqlErr()
+}
+
+// scopeValue marks position of scope value
+type scopeValue struct{}
+
+// scopeCond is the where or on condition that will be scoped
+type scopeCond struct {
+	keyword string
+	tables  []ident
+	cond    *cond
+}
+
+// scopeInsert is the insert statement that will be scoped
+type scopeInsert struct {
+	st *insertStmt
+}
+
+// scopeTable is the table statement that will be scoped
+type scopeTable struct {
+	table string
+}
+
+// scopeDeny is the statement that can not be scoped
+type scopeDeny struct {
+	statement string
+	tables    []ident
+}
+
+func (r *renderer) scopeValue() string {
+	r.scoped = true
+	return "$" + strconv.Itoa(r.scopeArg)
+}
+
+// matchScope returns qualifier of table if table is scoped,
+// records error if table is required to be scoped,
+// or table can not be parsed while scope is active
+func (r *renderer) matchScope(table ident) (string, bool) {
+	if (r.scope == nil && len(r.required) == 0) || strings.TrimSpace(table.value) == "" {
+		// no scope, or no table, ex. update in insert on conflict
+		return "", false
+	}
+	if _, _, ok := parseTable(table.value); !ok {
+		r.setErr(fmt.Errorf("%w: table %s", ErrUnscopable, strings.TrimSpace(table.value)))
+		return "", false
+	}
+	if r.scope != nil {
+		if p, ok := r.scope.match(table); ok {
+			return p, true
+		}
+	}
+	if _, ok := matchTable(r.required, table); ok {
+		r.setErr(fmt.Errorf("%w: %s", ErrScopeRequired, strings.TrimSpace(table.value)))
+	}
+	return "", false
+}
+
+func (r *renderer) scopeCond(x *scopeCond) string {
+	var preds []string
+	for _, t := range x.tables {
+		if p, ok := r.matchScope(t); ok {
+			preds = append(preds, p+"."+r.scope.Column+" = "+r.scopeValue())
+		}
+	}
+
+	var q []string
+	if !x.cond.empty() {
+		s := r.render([]any{x.cond}, " ")
+		if len(preds) > 0 && !x.cond.chain.empty() {
+			s = "(" + s + ")"
+		}
+		q = append(q, s)
+	}
+	q = append(q, preds...)
+	if len(q) == 0 {
+		return ""
+	}
+	return x.keyword + " " + strings.Join(q, " and ")
+}
+
+func (r *renderer) scopeInsert(x *scopeInsert) string {
+	if _, ok := r.matchScope(ident{value: x.st.table}); ok {
+		b, err := x.st.scoped(r.scope.Column)
+		if err == nil {
+			return r.render([]any{b}, " ")
+		}
+		r.setErr(err)
+	}
+	return r.render([]any{x.st.build()}, " ")
+}
+
+func (r *renderer) scopeTable(x *scopeTable) string {
+	if _, ok := r.matchScope(ident{value: x.table}); ok {
+		// table statement can not have where clause
+		var st selectStmt
+		st.Columns("*")
+		st.From(x.table)
+		return r.render([]any{st.make()}, " ")
+	}
+	return r.render([]any{"table", ident{value: x.table}}, " ")
+}
+
+func (r *renderer) scopeDeny(x *scopeDeny) {
+	for _, t := range x.tables {
+		if _, ok := r.matchScope(t); ok {
+			r.setErr(fmt.Errorf("%w: %s %s", ErrUnscopable, x.statement, strings.TrimSpace(t.value)))
+		}
+	}
+}
+
+// scopeTables returns table names from tables
+func scopeTables(tables ...[]any) []ident {
+	var xs []ident
+	for _, ts := range tables {
+		for _, t := range ts {
+			if b, ok := t.(*buffer); ok && len(b.q) == 1 {
+				t = b.q[0]
+			}
+			if t, ok := t.(ident); ok {
+				xs = append(xs, t)
+			}
+		}
+	}
+	return xs
+}
+
+// usingJoinTables returns tables of inner joins without on condition
+func usingJoinTables(joins []any) []any {
+	var xs []any
+	for _, j := range joins {
+		if j, ok := j.(*join); ok && j.on.empty() && !j.outer() {
+			xs = append(xs, j.table)
+		}
+	}
+	return xs
+}
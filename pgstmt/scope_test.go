@@ -0,0 +1,550 @@
+package pgstmt_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgctx"
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestScope(t *testing.T) {
+	t.Parallel()
+
+	scope := &pgstmt.Scope{
+		Column: "tenant_id",
+		Value:  7,
+		Tables: []string{"users", "orders", "public.invoices"},
+	}
+
+	cases := []struct {
+		name   string
+		result *pgstmt.Result
+		query  string
+		args   []any
+	}{
+		{
+			"select",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("u.id", "o.id")
+				b.From("users u")
+				b.LeftJoin("orders o").On(func(b pgstmt.Cond) {
+					b.EqRaw("o.user_id", "u.id")
+				})
+				b.Join("logs").Using("user_id")
+				b.Where(func(b pgstmt.Cond) {
+					b.Eq("u.status", "active")
+					b.Or(func(b pgstmt.Cond) {
+						b.Eq("u.role", "admin")
+					})
+				})
+			}),
+			`
+				select u.id, o.id
+				from users u
+				left join orders o on (o.user_id = u.id) and o.tenant_id = $3
+				join logs using (user_id)
+				where ((u.status = $1) or (u.role = $2)) and u.tenant_id = $3
+			`,
+			[]any{"active", "admin", 7},
+		},
+		{
+			"select without where",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("*")
+				b.From("public.users")
+			}),
+			"select * from public.users where public.users.tenant_id = $1",
+			[]any{7},
+		},
+		{
+			"subquery",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("*")
+				b.From("products p")
+				b.Where(func(b pgstmt.Cond) {
+					b.Exists(func(b pgstmt.SelectStatement) {
+						b.Columns("1")
+						b.From("orders o")
+						b.Where(func(b pgstmt.Cond) {
+							b.EqRaw("o.product_id", "p.id")
+						})
+					})
+				})
+			}),
+			`
+				select *
+				from products p
+				where (exists (select 1 from orders o where (o.product_id = p.id) and o.tenant_id = $1))
+			`,
+			[]any{7},
+		},
+		{
+			"subquery result",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("*")
+				b.FromResult(pgstmt.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("id")
+					b.From("users")
+					b.Where(func(b pgstmt.Cond) {
+						b.Eq("status", "active")
+					})
+				}), "t")
+				b.Where(func(b pgstmt.Cond) {
+					b.Gt("id", 10)
+				})
+			}),
+			`
+				select *
+				from (select id from users where (status = $1) and users.tenant_id = $3) t
+				where (id > $2)
+			`,
+			[]any{"active", 10, 7},
+		},
+		{
+			"not scoped table",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("*")
+				b.From("products")
+				b.Where(func(b pgstmt.Cond) {
+					b.Eq("id", 1)
+				})
+			}),
+			"select * from products where (id = $1)",
+			[]any{1},
+		},
+		{
+			"schema",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("*")
+				b.From("invoices", "public.invoices i", "archive.invoices")
+			}),
+			"select * from invoices, public.invoices i, archive.invoices where i.tenant_id = $1",
+			[]any{7},
+		},
+		{
+			"update",
+			pgstmt.Update(func(b pgstmt.UpdateStatement) {
+				b.Table("users")
+				b.Set("name").To("test")
+				b.Where(func(b pgstmt.Cond) {
+					b.Eq("id", 1)
+				})
+			}),
+			"update users set name = $1 where (id = $2) and users.tenant_id = $3",
+			[]any{"test", 1, 7},
+		},
+		{
+			"delete",
+			pgstmt.Delete(func(b pgstmt.DeleteStatement) {
+				b.From("orders")
+			}),
+			"delete from orders where orders.tenant_id = $1",
+			[]any{7},
+		},
+		{
+			"insert values",
+			pgstmt.Insert(func(b pgstmt.InsertStatement) {
+				b.Into("users")
+				b.Columns("name", "email")
+				b.Value("a", "a@example.com")
+				b.Value("b", "b@example.com")
+				b.Returning("id")
+			}),
+			"insert into users (name, email, tenant_id) values ($1, $2, $5), ($3, $4, $5) returning id",
+			[]any{"a", "a@example.com", "b", "b@example.com", 7},
+		},
+		{
+			"insert select",
+			pgstmt.Insert(func(b pgstmt.InsertStatement) {
+				b.Into("orders")
+				b.Columns("user_id")
+				b.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("id")
+					b.From("users")
+				})
+			}),
+			"insert into orders (user_id, tenant_id) select id, $1 from users where users.tenant_id = $1",
+			[]any{7},
+		},
+		{
+			"insert default values",
+			pgstmt.Insert(func(b pgstmt.InsertStatement) {
+				b.Into("orders")
+				b.DefaultValues()
+			}),
+			"insert into orders (tenant_id) values ($1)",
+			[]any{7},
+		},
+		{
+			"insert on conflict do update",
+			pgstmt.Insert(func(b pgstmt.InsertStatement) {
+				b.Into("users")
+				b.Columns("email", "name")
+				b.Value("a@example.com", "a")
+				b.OnConflictIndex("email").DoUpdate(func(b pgstmt.UpdateStatement) {
+					b.Set("name").ToRaw("excluded.name")
+					b.Where(func(b pgstmt.Cond) {
+						b.Eq("users.active", true)
+						b.Or(func(b pgstmt.Cond) {
+							b.IsNull("users.name")
+						})
+					})
+				})
+			}),
+			`
+				insert into users (email, name, tenant_id) values ($1, $2, $4)
+				on conflict (email) do update set name = excluded.name
+				where (((users.active = $3) or (users.name is null)) and users.tenant_id = excluded.tenant_id)
+			`,
+			[]any{"a@example.com", "a", true, 7},
+		},
+		{
+			"insert on conflict do update excluded",
+			pgstmt.Insert(func(b pgstmt.InsertStatement) {
+				b.Into("users as u")
+				b.Columns("email", "name")
+				b.Value("a@example.com", "a")
+				b.OnConflictIndex("email").DoUpdateExcluded("name")
+			}),
+			`
+				insert into users as u (email, name, tenant_id) values ($1, $2, $3)
+				on conflict (email) do update set name = excluded.name
+				where (u.tenant_id = excluded.tenant_id)
+			`,
+			[]any{"a@example.com", "a", 7},
+		},
+		{
+			"case folded table",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("*")
+				b.From("Users", `"Orders"`)
+			}),
+			`select * from Users, "Orders" where Users.tenant_id = $1`,
+			[]any{7},
+		},
+		{
+			"table",
+			pgstmt.Table("users"),
+			"select * from users where users.tenant_id = $1",
+			[]any{7},
+		},
+		{
+			"table not scoped",
+			pgstmt.Table("products"),
+			"table products",
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.result.Scope(scope)
+			assert.NoError(t, r.Err())
+			q, args := r.SQL()
+			assert.Equal(t, stripSpace(tc.query), q)
+			assert.EqualValues(t, tc.args, args)
+		})
+	}
+
+	t.Run("Unscopable", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			result *pgstmt.Result
+		}{
+			{
+				"insert without columns",
+				pgstmt.Insert(func(b pgstmt.InsertStatement) {
+					b.Into("users")
+					b.Value(1, "a")
+				}),
+			},
+			{
+				"insert with scope column",
+				pgstmt.Insert(func(b pgstmt.InsertStatement) {
+					b.Into("users")
+					b.Columns("name", "tenant_id")
+					b.Value("a", 99)
+				}),
+			},
+			{
+				"insert select with scope column",
+				pgstmt.Insert(func(b pgstmt.InsertStatement) {
+					b.Into("orders")
+					b.Columns("user_id", "Tenant_ID")
+					b.Select(func(b pgstmt.SelectStatement) {
+						b.Columns("id", "tenant_id")
+						b.From("products")
+					})
+				}),
+			},
+			{
+				"truncate",
+				pgstmt.Truncate(func(b pgstmt.TruncateStatement) {
+					b.Table("products")
+					b.Only("orders")
+				}),
+			},
+			{
+				"comma list",
+				pgstmt.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("*")
+					b.From("orders, users")
+				}),
+			},
+			{
+				"comma list with alias",
+				pgstmt.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("*")
+					b.From("orders o, users u")
+				}),
+			},
+			{
+				"inline join",
+				pgstmt.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("*")
+					b.From("users u join orders o on o.uid = u.id")
+				}),
+			},
+			{
+				"join string",
+				pgstmt.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("*")
+					b.From("products")
+					b.Join("orders o on o.product_id = products.id").Using("id")
+				}),
+			},
+			{
+				"update from comma list",
+				pgstmt.Update(func(b pgstmt.UpdateStatement) {
+					b.Table("products")
+					b.Set("name").To("a")
+					b.From("users, orders")
+				}),
+			},
+			{
+				"insert into invalid table",
+				pgstmt.Insert(func(b pgstmt.InsertStatement) {
+					b.Into("users u x")
+					b.Columns("name")
+					b.Value("a")
+				}),
+			},
+			{
+				"left join using",
+				pgstmt.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("u.id", "o.id")
+					b.From("users u")
+					b.LeftJoin("orders o").Using("user_id")
+				}),
+			},
+			{
+				"update where current of",
+				pgstmt.Update(func(b pgstmt.UpdateStatement) {
+					b.Table("users")
+					b.Set("name").To("a")
+					b.WhereCurrentOf("c")
+				}),
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				assert.NoError(t, tc.result.Err())
+				assert.ErrorIs(t, tc.result.Scope(scope).Err(), pgstmt.ErrUnscopable)
+			})
+		}
+	})
+
+	t.Run("Remove scope", func(t *testing.T) {
+		q, args := pgstmt.Delete(func(b pgstmt.DeleteStatement) {
+			b.From("orders")
+		}).Scope(scope).Scope(nil).SQL()
+		assert.Equal(t, "delete from orders", q)
+		assert.Empty(t, args)
+	})
+
+	t.Run("Bind and Format", func(t *testing.T) {
+		r, err := pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("id")
+			b.From("users")
+			b.Where(func(b pgstmt.Cond) {
+				b.Eq("id", pgstmt.Named("id"))
+			})
+		}).Scope(scope).Bind(map[string]any{"id": 1})
+		assert.NoError(t, err)
+
+		q, args := pgstmt.Format(r).SQL()
+		assert.Equal(t, "select id\nfrom users\nwhere (id = $1) and users.tenant_id = $2", q)
+		assert.EqualValues(t, []any{1, 7}, args)
+	})
+
+	t.Run("Bound embedded result", func(t *testing.T) {
+		sub, err := pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("user_id")
+			b.From("orders")
+			b.Where(func(b pgstmt.Cond) {
+				b.Eq("k", pgstmt.Named("x"))
+			})
+		}).Bind(map[string]any{"x": "inner"})
+		assert.NoError(t, err)
+
+		r, err := pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("id")
+			b.From("users")
+			b.Where(func(b pgstmt.Cond) {
+				b.Eq("k", pgstmt.Named("x"))
+				b.Eq("level", 5)
+				b.InResult("id", sub)
+			})
+		}).Bind(map[string]any{"x": "outer"})
+		assert.NoError(t, err)
+
+		q, args := r.SQL()
+		assert.Equal(t, "select id from users where (k = $1 and level = $2 and id in (select user_id from orders where (k = $3)))", q)
+		assert.EqualValues(t, []any{"outer", 5, "inner"}, args)
+
+		q, args = r.Scope(scope).SQL()
+		assert.Equal(t, stripSpace(`
+			select id from users
+			where (k = $1 and level = $2 and id in (select user_id from orders where (k = $3) and orders.tenant_id = $4))
+			and users.tenant_id = $4
+		`), q)
+		assert.EqualValues(t, []any{"outer", 5, "inner", 7}, args)
+	})
+}
+
+func TestScopeContext(t *testing.T) {
+	t.Parallel()
+
+	scope := &pgstmt.Scope{
+		Column: "tenant_id",
+		Value:  7,
+		Tables: []string{"users"},
+	}
+	r := pgstmt.Delete(func(b pgstmt.DeleteStatement) {
+		b.From("users")
+		b.Where(func(b pgstmt.Cond) {
+			b.Eq("id", 1)
+		})
+	})
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	ctx := pgctx.NewContext(context.Background(), db)
+	ctx = pgstmt.NewScopeContext(ctx, scope)
+
+	mock.ExpectExec(regexp.QuoteMeta("delete from users where (id = $1) and users.tenant_id = $2")).
+		WithArgs(1, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("delete from users where (id = $1)")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectQuery(regexp.QuoteMeta("explain (format json) delete from users where (id = $1) and users.tenant_id = $2")).
+		WithArgs(1, 7).
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(`[{"Plan": {"Node Type": "Delete"}}]`))
+
+	_, err = r.ExecWith(ctx)
+	assert.NoError(t, err)
+	_, err = r.ExecWith(pgstmt.Unscoped(ctx))
+	assert.NoError(t, err)
+	_, err = pgctx.Explain(ctx, r, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRequireScope(t *testing.T) {
+	t.Parallel()
+
+	scope := &pgstmt.Scope{
+		Column: "tenant_id",
+		Value:  7,
+		Tables: []string{"accounts"},
+	}
+	r := pgstmt.Select(func(b pgstmt.SelectStatement) {
+		b.Columns("id")
+		b.From("accounts")
+	})
+	assert.NoError(t, r.Err())
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	ctx := pgctx.NewContext(context.Background(), db)
+	ctx = pgstmt.RequireScope(ctx, "accounts", "public.notes")
+
+	mock.ExpectQuery(regexp.QuoteMeta("select id from accounts")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta("select id from accounts where accounts.tenant_id = $1")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta("select id from accounts where accounts.tenant_id = $1")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta("select id from accounts")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	t.Run("Without scope", func(t *testing.T) {
+		_, err := r.QueryWith(ctx)
+		assert.ErrorIs(t, err, pgstmt.ErrScopeRequired)
+		_, err = r.QueryContext(ctx, db.QueryContext)
+		assert.ErrorIs(t, err, pgstmt.ErrScopeRequired)
+		_, err = r.Scope(scope).Scope(nil).QueryWith(ctx)
+		assert.ErrorIs(t, err, pgstmt.ErrScopeRequired)
+	})
+
+	t.Run("Scope does not cover table", func(t *testing.T) {
+		_, err := pgstmt.Delete(func(b pgstmt.DeleteStatement) {
+			b.From("public.notes")
+		}).ExecWith(pgstmt.NewScopeContext(ctx, scope))
+		assert.ErrorIs(t, err, pgstmt.ErrScopeRequired)
+
+		_, err = pgstmt.Delete(func(b pgstmt.DeleteStatement) {
+			b.From("public.notes")
+		}).Scope(scope).ExecWith(ctx)
+		assert.ErrorIs(t, err, pgstmt.ErrScopeRequired)
+	})
+
+	t.Run("Table can not be parsed", func(t *testing.T) {
+		_, err := pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("*")
+			b.From("notes n, accounts a")
+		}).QueryWith(ctx)
+		assert.ErrorIs(t, err, pgstmt.ErrUnscopable)
+	})
+
+	t.Run("Embedded result", func(t *testing.T) {
+		x := pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("*")
+			b.From("products")
+			b.Where(func(b pgstmt.Cond) {
+				b.InResult("account_id", r)
+			})
+		})
+		_, err := x.QueryWith(ctx)
+		assert.ErrorIs(t, err, pgstmt.ErrScopeRequired)
+	})
+
+	t.Run("Not required", func(t *testing.T) {
+		_, err := r.QueryWith(pgctx.NewContext(context.Background(), db))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Scoped", func(t *testing.T) {
+		_, err := r.QueryWith(pgstmt.NewScopeContext(ctx, scope))
+		assert.NoError(t, err)
+		_, err = r.Scope(scope).QueryWith(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Unscoped", func(t *testing.T) {
+		_, err := r.QueryWith(pgstmt.Unscoped(ctx))
+		assert.NoError(t, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
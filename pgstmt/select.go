@@ -1,21 +1,82 @@
 package pgstmt
 
+import (
+	"context"
+	"strings"
+
+	"github.com/acoshift/pgsql"
+)
+
 // Select builds select statement
 func Select(f func(b SelectStatement)) *Result {
 	var st selectStmt
 	f(&st)
-	return newResult(build(st.make()))
+	return newResult(st.make())
+}
+
+// NewSelect creates new reusable select statement builder
+func NewSelect() SelectBuilder {
+	return &selectStmt{}
+}
+
+// SelectBuilder is the reusable select statement builder,
+// the statement can be modified, cloned and built many times
+type SelectBuilder interface {
+	SelectStatement
+
+	// Clone returns a copy of the statement,
+	// modifying the copy does not affect the original
+	Clone() SelectBuilder
+
+	// Build builds the statement
+	Build() *Result
+
+	// HasWhere checks is statement has where condition
+	HasWhere() bool
+
+	// HasOrderBy checks is statement has order by
+	HasOrderBy() bool
+
+	// LimitValue returns limit or fetch first count, false if no limit or limit all
+	LimitValue() (int64, bool)
+
+	// OffsetValue returns offset, false if no offset
+	OffsetValue() (int64, bool)
+
+	// ResetOrderBy removes order by
+	ResetOrderBy()
+
+	// ResetLimit removes limit, offset and fetch first
+	ResetLimit()
+
+	// BuildCount builds count(*) query of the statement without order by, limit and offset,
+	// statement with distinct, group by or having will be wrapped in a subquery
+	BuildCount() *Result
+
+	// BuildWithTotal builds the statement with total rows as the last column,
+	// count(*) over() or count subquery for statement with distinct
+	BuildWithTotal() *Result
+
+	// IterWithTotal iterates rows and returns total rows without limit and offset,
+	// iter must not scan the total column.
+	//
+	// The total is selected in one round trip with BuildWithTotal,
+	// except empty page (ex. limit 0 or offset out of range) which counts in another query.
+	IterWithTotal(ctx context.Context, iter pgsql.Iterator) (total int64, err error)
 }
 
 // SelectStatement is the select statement builder
 type SelectStatement interface {
+	With(name string, r *Result)
 	Distinct() Distinct
 	Columns(col ...any)
 	ColumnSelect(f func(b SelectStatement), as string)
 	ColumnExists(f func(b SelectStatement))
+	ColumnResult(r *Result, as string)
 	From(table ...string)
 	FromSelect(f func(b SelectStatement), as string)
 	FromValues(f func(b Values), as string)
+	FromResult(r *Result, as string)
 
 	Join(table string) Join
 	InnerJoin(table string) Join
@@ -41,12 +102,28 @@ type SelectStatement interface {
 	LeftJoinUnion(f func(b UnionStatement), as string) Join
 	RightJoinUnion(f func(b UnionStatement), as string) Join
 
+	JoinResult(r *Result, as string) Join
+	InnerJoinResult(r *Result, as string) Join
+	FullOuterJoinResult(r *Result, as string) Join
+	LeftJoinResult(r *Result, as string) Join
+	RightJoinResult(r *Result, as string) Join
+
 	Where(f func(b Cond))
 	GroupBy(col ...string)
+	GroupByExpr(col ...any)
 	Having(f func(b Cond))
 	OrderBy(col string) OrderBy
+	OrderByExpr(col any) OrderBy
 	Limit(n int64)
+	LimitArg(n int64)
+	LimitAll()
 	Offset(n int64)
+	OffsetArg(n int64)
+	FetchFirst(n int64) Fetch
+	FetchFirstArg(n int64) Fetch
+
+	// When calls f if ok is true
+	When(ok bool, f func(b SelectStatement))
 }
 
 type Distinct interface {
@@ -71,6 +148,7 @@ type Join interface {
 }
 
 type selectStmt struct {
+	with     group
 	distinct *distinct
 	columns  group
 	from     group
@@ -79,8 +157,80 @@ type selectStmt struct {
 	groupBy  group
 	having   cond
 	orderBy  group
-	limit    *int64
-	offset   *int64
+	limitOffset
+}
+
+func (st *selectStmt) Clone() SelectBuilder {
+	return st.clone()
+}
+
+func (st *selectStmt) clone() *selectStmt {
+	x := *st
+	x.with = st.with.clone()
+	if st.distinct != nil {
+		d := *st.distinct
+		d.columns.group = d.columns.group.clone()
+		x.distinct = &d
+	}
+	x.columns = st.columns.clone()
+	x.from = st.from.clone()
+	x.joins = st.joins.clone()
+	for i, j := range x.joins.q {
+		if j, ok := j.(*join); ok {
+			x.joins.q[i] = j.clone()
+		}
+	}
+	x.where = st.where.clone()
+	x.groupBy = st.groupBy.clone()
+	x.having = st.having.clone()
+	x.orderBy = st.orderBy.clone()
+	for i, o := range x.orderBy.q {
+		if o, ok := o.(*orderBy); ok {
+			p := *o
+			x.orderBy.q[i] = &p
+		}
+	}
+	if st.fetch != nil {
+		f := *st.fetch
+		x.fetch = &f
+	}
+	return &x
+}
+
+func (st *selectStmt) Build() *Result {
+	// build from a copy, result must not change when statement is modified
+	return newResult(st.clone().make())
+}
+
+func (st *selectStmt) HasWhere() bool {
+	return !st.where.empty()
+}
+
+func (st *selectStmt) HasOrderBy() bool {
+	return !st.orderBy.empty()
+}
+
+func (st *selectStmt) LimitValue() (int64, bool) {
+	if st.fetch != nil {
+		return int64Value(st.fetch.n)
+	}
+	return int64Value(st.limit)
+}
+
+func (st *selectStmt) OffsetValue() (int64, bool) {
+	return int64Value(st.offset)
+}
+
+func (st *selectStmt) ResetOrderBy() {
+	st.orderBy = group{}
+}
+
+func (st *selectStmt) ResetLimit() {
+	st.limitOffset = limitOffset{}
+}
+
+func (st *selectStmt) With(name string, r *Result) {
+	st.with.push(withGroup(" ", name, "as", r))
 }
 
 func (st *selectStmt) Distinct() Distinct {
@@ -113,8 +263,17 @@ func (st *selectStmt) ColumnExists(f func(b SelectStatement)) {
 	st.columns.push(&b)
 }
 
+func (st *selectStmt) ColumnResult(r *Result, as string) {
+	var b buffer
+	b.push(r)
+	if as != "" {
+		b.push(as)
+	}
+	st.columns.push(&b)
+}
+
 func (st *selectStmt) From(table ...string) {
-	st.from.pushString(table...)
+	st.from.pushTable(table...)
 }
 
 func (st *selectStmt) FromSelect(f func(b SelectStatement), as string) {
@@ -136,21 +295,21 @@ func (st *selectStmt) FromValues(f func(b Values), as string) {
 	if x.empty() {
 		return
 	}
+	st.from.push(x.from(as))
+}
 
-	st.from.push(withGroup(" ",
-		withGroup(" ",
-			withParen(" ",
-				"values",
-				withGroup(", ", x.q...),
-			),
-		),
-		as,
-	))
+func (st *selectStmt) FromResult(r *Result, as string) {
+	var b buffer
+	b.push(r)
+	if as != "" {
+		b.push(as)
+	}
+	st.from.push(&b)
 }
 
 func (st *selectStmt) join(typ, table string) Join {
 	var b buffer
-	b.push(table)
+	b.push(ident{value: table, alias: true})
 	x := join{
 		typ:   typ,
 		table: &b,
@@ -275,6 +434,41 @@ func (st *selectStmt) RightJoinUnion(f func(b UnionStatement), as string) Join {
 	return st.joinUnion("right join", f, as)
 }
 
+func (st *selectStmt) joinResult(typ string, r *Result, as string) Join {
+	var b buffer
+	b.push(r)
+	if as != "" {
+		b.push(as)
+	}
+
+	j := join{
+		typ:   typ,
+		table: &b,
+	}
+	st.joins.push(&j)
+	return &j
+}
+
+func (st *selectStmt) JoinResult(r *Result, as string) Join {
+	return st.joinResult("join", r, as)
+}
+
+func (st *selectStmt) InnerJoinResult(r *Result, as string) Join {
+	return st.joinResult("inner join", r, as)
+}
+
+func (st *selectStmt) FullOuterJoinResult(r *Result, as string) Join {
+	return st.joinResult("full outer join", r, as)
+}
+
+func (st *selectStmt) LeftJoinResult(r *Result, as string) Join {
+	return st.joinResult("left join", r, as)
+}
+
+func (st *selectStmt) RightJoinResult(r *Result, as string) Join {
+	return st.joinResult("right join", r, as)
+}
+
 func (st *selectStmt) Where(f func(b Cond)) {
 	f(&st.where)
 }
@@ -283,11 +477,21 @@ func (st *selectStmt) GroupBy(col ...string) {
 	st.groupBy.pushString(col...)
 }
 
+// GroupByExpr adds group by expressions, ex. Expr, Case
+func (st *selectStmt) GroupByExpr(col ...any) {
+	st.groupBy.push(col...)
+}
+
 func (st *selectStmt) Having(f func(b Cond)) {
 	f(&st.having)
 }
 
 func (st *selectStmt) OrderBy(col string) OrderBy {
+	return st.OrderByExpr(col)
+}
+
+// OrderByExpr adds order by expression, ex. Expr, Case
+func (st *selectStmt) OrderByExpr(col any) OrderBy {
 	p := orderBy{
 		col: col,
 	}
@@ -295,16 +499,11 @@ func (st *selectStmt) OrderBy(col string) OrderBy {
 	return &p
 }
 
-func (st *selectStmt) Limit(n int64) {
-	st.limit = &n
-}
-
-func (st *selectStmt) Offset(n int64) {
-	st.offset = &n
-}
-
 func (st *selectStmt) make() *buffer {
 	var b buffer
+	if !st.with.empty() {
+		b.push("with", &st.with)
+	}
 	b.push("select")
 	if st.distinct != nil {
 		b.push("distinct")
@@ -325,8 +524,8 @@ func (st *selectStmt) make() *buffer {
 			b.push(st.joins.q...)
 		}
 	}
-	if !st.where.empty() {
-		b.push("where", &st.where)
+	if tables := scopeTables(st.from.q, usingJoinTables(st.joins.q)); len(tables) > 0 || !st.where.empty() {
+		b.push(&scopeCond{"where", tables, &st.where})
 	}
 	if !st.groupBy.empty() {
 		b.push("group by", paren(&st.groupBy))
@@ -337,12 +536,7 @@ func (st *selectStmt) make() *buffer {
 	if !st.orderBy.empty() {
 		b.push("order by", &st.orderBy)
 	}
-	if st.limit != nil {
-		b.push("limit", *st.limit)
-	}
-	if st.offset != nil {
-		b.push("offset", *st.offset)
-	}
+	st.limitOffset.make(&b)
 
 	return &b
 }
@@ -354,12 +548,19 @@ type join struct {
 	on    cond
 }
 
+func (st *join) clone() *join {
+	x := *st
+	x.using = st.using.clone()
+	x.on = st.on.clone()
+	return &x
+}
+
 func (st *join) On(f func(b Cond)) {
 	f(&st.on)
 }
 
 func (st *join) Using(col ...string) {
-	st.using.push(parenString(col...))
+	st.using.push(parenIdent(col...))
 }
 
 func (st *join) build() []any {
@@ -370,13 +571,23 @@ func (st *join) build() []any {
 		b.push(&st.using)
 	}
 	if !st.on.empty() {
-		b.push("on", &st.on)
+		b.push(&scopeCond{"on", scopeTables([]any{st.table}), &st.on})
+	} else if st.outer() {
+		// scope predicate in where clause turns outer join into inner join,
+		// and using can not have on condition
+		b.push(&scopeDeny{st.typ + " using", scopeTables([]any{st.table})})
 	}
 	return b.q
 }
 
+func (st *join) outer() bool {
+	return strings.HasPrefix(st.typ, "left ") ||
+		strings.HasPrefix(st.typ, "right ") ||
+		strings.HasPrefix(st.typ, "full ")
+}
+
 type orderBy struct {
-	col       string
+	col       any
 	direction string
 	nulls     string
 }
@@ -413,24 +624,6 @@ func (st *orderBy) build() []any {
 	return b.q
 }
 
-type values struct {
-	group
-}
-
-func (st *values) Value(value ...any) {
-	var x parenGroup
-	for _, v := range value {
-		x.push(Arg(v))
-	}
-	st.push(&x)
-}
-
-func (st *values) Values(values ...[]any) {
-	for _, value := range values {
-		st.Value(value...)
-	}
-}
-
 type distinct struct {
 	columns parenGroup
 }
@@ -592,3 +592,65 @@ func TestSelect(t *testing.T) {
 		})
 	}
 }
+
+func TestNewSelect(t *testing.T) {
+	t.Parallel()
+
+	b := pgstmt.NewSelect()
+	b.Columns("id", "name")
+	b.From("users")
+	b.Where(func(b pgstmt.Cond) {
+		b.Eq("status", "active")
+	})
+	o := b.OrderBy("created_at")
+	b.Limit(10)
+	b.Offset(20)
+
+	assert.True(t, b.HasWhere())
+	assert.True(t, b.HasOrderBy())
+	limit, ok := b.LimitValue()
+	assert.True(t, ok)
+	assert.EqualValues(t, 10, limit)
+	offset, ok := b.OffsetValue()
+	assert.True(t, ok)
+	assert.EqualValues(t, 20, offset)
+
+	r := b.Build()
+
+	c := b.Clone()
+	c.Where(func(b pgstmt.Cond) {
+		b.Eq("tenant_id", 1)
+	})
+	c.ResetOrderBy()
+	c.ResetLimit()
+	c.LimitArg(5)
+	assert.False(t, c.HasOrderBy())
+
+	// modify original after clone and build
+	o.Desc()
+	b.Columns("email")
+
+	q, args := r.SQL()
+	assert.Equal(t, "select id, name from users where (status = $1) order by created_at limit 10 offset 20", q)
+	assert.EqualValues(t, []any{"active"}, args)
+
+	q, args = c.Build().SQL()
+	assert.Equal(t, "select id, name from users where (status = $1 and tenant_id = $2) limit $3", q)
+	assert.EqualValues(t, []any{"active", 1, int64(5)}, args)
+
+	q, _ = b.Build().SQL()
+	assert.Equal(t, "select id, name, email from users where (status = $1) order by created_at desc limit 10 offset 20", q)
+
+	// result is a snapshot, formatting must not see later modifications
+	b.ResetLimit()
+	q, _ = pgstmt.Format(r).SQL()
+	assert.Contains(t, q, "limit 10")
+
+	_, ok = b.LimitValue()
+	assert.False(t, ok)
+
+	b.FetchFirst(5)
+	limit, ok = b.LimitValue()
+	assert.True(t, ok)
+	assert.EqualValues(t, 5, limit)
+}
@@ -0,0 +1,127 @@
+package pgstmt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestResultSubquery(t *testing.T) {
+	t.Parallel()
+
+	activeUsers := pgstmt.Select(func(b pgstmt.SelectStatement) {
+		b.Columns("id")
+		b.From("users")
+		b.Where(func(b pgstmt.Cond) {
+			b.Eq("status", "active")
+			b.Gt("level", 1)
+		})
+	})
+
+	cases := []struct {
+		name   string
+		result *pgstmt.Result
+		query  string
+		args   []any
+	}{
+		{
+			"in",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("*")
+				b.From("orders")
+				b.Where(func(b pgstmt.Cond) {
+					b.Eq("paid", true)
+					b.InResult("user_id", activeUsers)
+					b.Field("owner_id").NotIn().Result(activeUsers)
+				})
+			}),
+			`select * from orders
+			where (paid = $1
+				and user_id in (select id from users where (status = $2 and level > $3))
+				and owner_id not in (select id from users where (status = $4 and level > $5)))`,
+			[]any{true, "active", 1, "active", 1},
+		},
+		{
+			"cte",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.With("active_users", activeUsers)
+				b.Columns("o.*")
+				b.ColumnResult(pgstmt.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("count(*)")
+					b.From("items i")
+					b.Where(func(b pgstmt.Cond) {
+						b.EqRaw("i.order_id", "o.id")
+						b.Gt("i.price", 10)
+					})
+				}), "item_count")
+				b.FromResult(pgstmt.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("*")
+					b.From("orders")
+					b.Where(func(b pgstmt.Cond) {
+						b.Eq("year", 2020)
+					})
+				}), "o")
+				b.InnerJoin("active_users u").On(func(b pgstmt.Cond) {
+					b.EqRaw("u.id", "o.user_id")
+				})
+				b.LeftJoinResult(activeUsers, "a").Using("id")
+				b.Where(func(b pgstmt.Cond) {
+					b.Eq("o.status", 2)
+				})
+			}),
+			`with active_users as (select id from users where (status = $1 and level > $2))
+			select o.*, (select count(*) from items i where (i.order_id = o.id and i.price > $3)) item_count
+			from (select * from orders where (year = $4)) o
+			inner join active_users u on (u.id = o.user_id)
+			left join (select id from users where (status = $5 and level > $6)) a using (id)
+			where (o.status = $7)`,
+			[]any{"active", 1, 10, 2020, "active", 1, 2},
+		},
+		{
+			"reuse arguments",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns("*")
+				b.From("users")
+				b.Where(func(b pgstmt.Cond) {
+					b.InResult("id", pgstmt.Select(func(b pgstmt.SelectStatement) {
+						b.Columns("user_id")
+						b.From("logs")
+						b.Where(func(b pgstmt.Cond) {
+							b.Eq("a", pgstmt.Named("x"))
+							b.Eq("b", pgstmt.Named("x"))
+						})
+					}))
+				})
+			}),
+			`select * from users where (id in (select user_id from logs where (a = $1 and b = $1)))`,
+			nil,
+		},
+		{
+			"placeholder in comment",
+			pgstmt.Select(func(b pgstmt.SelectStatement) {
+				b.Columns(pgstmt.Arg(1))
+				b.FromResult(pgstmt.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("id /* $1 */", "$$ $1 $$ as body")
+					b.From("users")
+					b.Where(func(b pgstmt.Cond) {
+						b.Eq("id", 2)
+					})
+				}), "u")
+			}),
+			`select $1 from (select id /* $1 */, $$ $1 $$ as body from users where (id = $2)) u`,
+			[]any{1, 2},
+		},
+	}
+
+	for _, tC := range cases {
+		t.Run(tC.name, func(t *testing.T) {
+			q, args := tC.result.SQL()
+			assert.Equal(t, stripSpace(tC.query), q)
+			if tC.args != nil {
+				assert.EqualValues(t, tC.args, args)
+			}
+		})
+	}
+}
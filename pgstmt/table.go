@@ -0,0 +1,620 @@
+package pgstmt
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lib/pq"
+)
+
+// ddl marks statement as ddl,
+// arguments are inlined as sql literals and identifiers are always quoted
+type ddl struct {
+	b *buffer
+}
+
+func newDDL(b *buffer) *buffer {
+	var x buffer
+	x.push(ddl{b})
+	return &x
+}
+
+// ddlLiteral converts argument into sql literal for ddl,
+// ddl can not have parameters.
+//
+// []byte converts to bytea, []byte from driver.Valuer converts to text (ex. json).
+func ddlLiteral(v any) (string, error) {
+	switch x := v.(type) {
+	case nil:
+		return "null", nil
+	case named:
+		return "", fmt.Errorf("pgstmt: named argument %q can not be used in ddl", x.name)
+	case time.Time:
+		return pq.QuoteLiteral(string(pq.FormatTimestamp(x))), nil
+	case []byte:
+		if x == nil {
+			return "null", nil
+		}
+		return `'\x` + hex.EncodeToString(x) + `'::bytea`, nil
+	case driver.Valuer:
+		if rv := reflect.ValueOf(x); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return "null", nil
+		}
+		dv, err := x.Value()
+		if err != nil {
+			return "", fmt.Errorf("pgstmt: can not convert %T to ddl literal; %w", v, err)
+		}
+		if p, ok := dv.([]byte); ok {
+			if !utf8.Valid(p) {
+				return "", fmt.Errorf("pgstmt: can not convert %T to ddl literal; invalid utf-8 value", v)
+			}
+			return pq.QuoteLiteral(string(p)), nil
+		}
+		return ddlLiteral(dv)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "null", nil
+		}
+		return ddlLiteral(rv.Elem().Interface())
+	case reflect.String:
+		return pq.QuoteLiteral(rv.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			// NaN and Infinity must be quoted
+			return pq.QuoteLiteral(strconv.FormatFloat(f, 'g', -1, 64)), nil
+		}
+		return strconv.FormatFloat(f, 'g', -1, rv.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("pgstmt: can not convert %T to ddl literal", v)
+}
+
+// CreateTable builds create table statement
+func CreateTable(f func(b CreateTableStatement)) *Result {
+	var st createTableStmt
+	f(&st)
+	return newResult(newDDL(st.make()))
+}
+
+// CreateTableStatement is the create table statement builder
+type CreateTableStatement interface {
+	Table(table string)
+	IfNotExists()
+
+	// Column adds column definition, typ is the raw sql type (ex. "varchar(20)")
+	Column(name, typ string) Column
+
+	// Constraint adds table constraint, empty name for unnamed constraint
+	Constraint(name string) Constraint
+
+	// PartitionOf creates table as a partition of parent table
+	PartitionOf(parent string) PartitionBound
+
+	// PartitionBy creates partitioned table, method is range, list or hash
+	PartitionBy(method string, key ...string)
+}
+
+// Column is the column definition builder.
+//
+// Values are inlined as sql literals, use Raw for expression (ex. Raw("now()")).
+type Column interface {
+	NotNull() Column
+	Null() Column
+	Default(value any) Column
+	PrimaryKey() Column
+	Unique() Column
+	Check(f func(b Cond)) Column
+	References(table string, col ...string) ForeignKey
+}
+
+// Constraint is the table constraint builder
+type Constraint interface {
+	PrimaryKey(col ...string)
+	Unique(col ...string)
+	Check(f func(b Cond))
+	ForeignKey(col ...string) ForeignKey
+}
+
+// ForeignKey is the foreign key constraint builder
+type ForeignKey interface {
+	References(table string, col ...string) ForeignKey
+
+	// OnDelete sets referential action, ex. cascade, set null
+	OnDelete(action string) ForeignKey
+
+	// OnUpdate sets referential action, ex. cascade, set null
+	OnUpdate(action string) ForeignKey
+}
+
+// PartitionBound is the partition bound builder.
+//
+// Values are inlined as sql literals, use Raw for minvalue and maxvalue.
+type PartitionBound interface {
+	ForValuesIn(value ...any)
+	ForValuesFrom(value ...any) PartitionRange
+	ForValuesWith(modulus, remainder int)
+	Default()
+}
+
+type PartitionRange interface {
+	To(value ...any)
+}
+
+type createTableStmt struct {
+	table         string
+	ifNotExists   bool
+	defs          parenGroup
+	partitionOf   string
+	bound         *partitionBound
+	partitionBy   string
+	partitionKeys []string
+}
+
+func (st *createTableStmt) Table(table string) {
+	st.table = table
+}
+
+func (st *createTableStmt) IfNotExists() {
+	st.ifNotExists = true
+}
+
+func (st *createTableStmt) Column(name, typ string) Column {
+	x := column{name: name, typ: typ}
+	st.defs.push(&x)
+	return &x
+}
+
+func (st *createTableStmt) Constraint(name string) Constraint {
+	x := constraint{name: name}
+	st.defs.push(&x)
+	return &x
+}
+
+func (st *createTableStmt) PartitionOf(parent string) PartitionBound {
+	st.partitionOf = parent
+	st.bound = &partitionBound{}
+	return st.bound
+}
+
+func (st *createTableStmt) PartitionBy(method string, key ...string) {
+	st.partitionBy = method
+	st.partitionKeys = key
+}
+
+func (st *createTableStmt) make() *buffer {
+	var b buffer
+	b.push("create table")
+	if st.ifNotExists {
+		b.push("if not exists")
+	}
+	b.push(ident{value: st.table})
+	if st.partitionOf != "" {
+		b.push("partition of", ident{value: st.partitionOf})
+		if !st.defs.empty() {
+			b.push(&st.defs)
+		}
+		b.push(st.bound)
+	} else if st.defs.empty() {
+		b.push("()")
+	} else {
+		b.push(&st.defs)
+	}
+	if st.partitionBy != "" {
+		b.push("partition by", st.partitionBy, parenIdent(st.partitionKeys...))
+	}
+	return &b
+}
+
+type column struct {
+	name string
+	typ  string
+	q    []any
+}
+
+func (st *column) NotNull() Column {
+	st.q = append(st.q, "not null")
+	return st
+}
+
+func (st *column) Null() Column {
+	st.q = append(st.q, "null")
+	return st
+}
+
+func (st *column) Default(value any) Column {
+	st.q = append(st.q, "default", Arg(value))
+	return st
+}
+
+func (st *column) PrimaryKey() Column {
+	st.q = append(st.q, "primary key")
+	return st
+}
+
+func (st *column) Unique() Column {
+	st.q = append(st.q, "unique")
+	return st
+}
+
+func (st *column) Check(f func(b Cond)) Column {
+	st.q = append(st.q, "check", checkCond(f))
+	return st
+}
+
+func (st *column) References(table string, col ...string) ForeignKey {
+	var x foreignKey
+	x.References(table, col...)
+	st.q = append(st.q, &x)
+	return &x
+}
+
+func (st *column) build() []any {
+	var b buffer
+	b.push(ident{value: st.name}, st.typ)
+	b.push(st.q...)
+	return b.q
+}
+
+// checkCond builds check constraint expression
+func checkCond(f func(b Cond)) any {
+	var c cond
+	f(&c)
+	q := c.build()
+	if len(q) == 1 {
+		if _, ok := q[0].(*parenGroup); ok {
+			return q[0]
+		}
+	}
+	return withParen(" ", q...)
+}
+
+type constraint struct {
+	name string
+	q    []any
+}
+
+func (st *constraint) PrimaryKey(col ...string) {
+	st.q = []any{"primary key", parenIdent(col...)}
+}
+
+func (st *constraint) Unique(col ...string) {
+	st.q = []any{"unique", parenIdent(col...)}
+}
+
+func (st *constraint) Check(f func(b Cond)) {
+	st.q = []any{"check", checkCond(f)}
+}
+
+func (st *constraint) ForeignKey(col ...string) ForeignKey {
+	x := foreignKey{columns: col}
+	st.q = []any{&x}
+	return &x
+}
+
+func (st *constraint) build() []any {
+	var b buffer
+	if st.name != "" {
+		b.push("constraint", ident{value: st.name})
+	}
+	b.push(st.q...)
+	return b.q
+}
+
+type foreignKey struct {
+	columns    []string
+	table      string
+	refColumns []string
+	onDelete   string
+	onUpdate   string
+}
+
+func (st *foreignKey) References(table string, col ...string) ForeignKey {
+	st.table = table
+	st.refColumns = col
+	return st
+}
+
+func (st *foreignKey) OnDelete(action string) ForeignKey {
+	st.onDelete = action
+	return st
+}
+
+func (st *foreignKey) OnUpdate(action string) ForeignKey {
+	st.onUpdate = action
+	return st
+}
+
+func (st *foreignKey) build() []any {
+	var b buffer
+	if len(st.columns) > 0 {
+		b.push("foreign key", parenIdent(st.columns...))
+	}
+	b.push("references", ident{value: st.table})
+	if len(st.refColumns) > 0 {
+		b.push(parenIdent(st.refColumns...))
+	}
+	if st.onDelete != "" {
+		b.push("on delete", st.onDelete)
+	}
+	if st.onUpdate != "" {
+		b.push("on update", st.onUpdate)
+	}
+	return b.q
+}
+
+type partitionBound struct {
+	q []any
+}
+
+func (st *partitionBound) ForValuesIn(value ...any) {
+	st.q = []any{"for values in", paren(argValues(value)...)}
+}
+
+func (st *partitionBound) ForValuesFrom(value ...any) PartitionRange {
+	st.q = []any{"for values from", paren(argValues(value)...)}
+	return st
+}
+
+func (st *partitionBound) To(value ...any) {
+	st.q = append(st.q, "to", paren(argValues(value)...))
+}
+
+func (st *partitionBound) ForValuesWith(modulus, remainder int) {
+	st.q = []any{"for values with", paren(withGroup(" ", "modulus", modulus), withGroup(" ", "remainder", remainder))}
+}
+
+func (st *partitionBound) Default() {
+	st.q = []any{"default"}
+}
+
+func (st *partitionBound) build() []any {
+	return st.q
+}
+
+func argValues(values []any) []any {
+	xs := make([]any, len(values))
+	for i, v := range values {
+		xs[i] = Arg(v)
+	}
+	return xs
+}
+
+// AlterTable builds alter table statement
+func AlterTable(f func(b AlterTableStatement)) *Result {
+	var st alterTableStmt
+	f(&st)
+	return newResult(newDDL(st.make()))
+}
+
+// AlterTableStatement is the alter table statement builder
+type AlterTableStatement interface {
+	Table(table string)
+	IfExists()
+
+	AddColumn(name, typ string) Column
+	AddColumnIfNotExists(name, typ string) Column
+	AlterColumn(name string) AlterColumn
+	DropColumn(name string) Drop
+	RenameColumn(name, newName string)
+
+	AddConstraint(name string) Constraint
+	DropConstraint(name string) Drop
+
+	AttachPartition(table string) PartitionBound
+	DetachPartition(table string)
+
+	RenameTo(newName string)
+}
+
+// AlterColumn is the alter column action builder
+type AlterColumn interface {
+	// Type sets column type, typ is the raw sql type
+	Type(typ string) AlterColumn
+	SetDefault(value any) AlterColumn
+	DropDefault() AlterColumn
+	SetNotNull() AlterColumn
+	DropNotNull() AlterColumn
+}
+
+// Drop is the drop action builder
+type Drop interface {
+	IfExists() Drop
+	Cascade() Drop
+}
+
+type alterTableStmt struct {
+	table    string
+	ifExists bool
+	actions  group
+}
+
+func (st *alterTableStmt) Table(table string) {
+	st.table = table
+}
+
+func (st *alterTableStmt) IfExists() {
+	st.ifExists = true
+}
+
+func (st *alterTableStmt) AddColumn(name, typ string) Column {
+	x := column{name: name, typ: typ}
+	st.actions.push(withGroup(" ", "add column", &x))
+	return &x
+}
+
+func (st *alterTableStmt) AddColumnIfNotExists(name, typ string) Column {
+	x := column{name: name, typ: typ}
+	st.actions.push(withGroup(" ", "add column if not exists", &x))
+	return &x
+}
+
+func (st *alterTableStmt) AlterColumn(name string) AlterColumn {
+	return &alterColumn{st, name}
+}
+
+func (st *alterTableStmt) DropColumn(name string) Drop {
+	x := drop{kind: "drop column", name: name}
+	st.actions.push(&x)
+	return &x
+}
+
+func (st *alterTableStmt) RenameColumn(name, newName string) {
+	st.actions.push(withGroup(" ", "rename column", ident{value: name}, "to", ident{value: newName}))
+}
+
+func (st *alterTableStmt) AddConstraint(name string) Constraint {
+	x := constraint{name: name}
+	st.actions.push(withGroup(" ", "add", &x))
+	return &x
+}
+
+func (st *alterTableStmt) DropConstraint(name string) Drop {
+	x := drop{kind: "drop constraint", name: name}
+	st.actions.push(&x)
+	return &x
+}
+
+func (st *alterTableStmt) AttachPartition(table string) PartitionBound {
+	var x partitionBound
+	st.actions.push(withGroup(" ", "attach partition", ident{value: table}, &x))
+	return &x
+}
+
+func (st *alterTableStmt) DetachPartition(table string) {
+	st.actions.push(withGroup(" ", "detach partition", ident{value: table}))
+}
+
+func (st *alterTableStmt) RenameTo(newName string) {
+	st.actions.push(withGroup(" ", "rename to", ident{value: newName}))
+}
+
+func (st *alterTableStmt) make() *buffer {
+	var b buffer
+	b.push("alter table")
+	if st.ifExists {
+		b.push("if exists")
+	}
+	b.push(ident{value: st.table}, &st.actions)
+	return &b
+}
+
+type alterColumn struct {
+	st   *alterTableStmt
+	name string
+}
+
+func (x *alterColumn) push(q ...any) AlterColumn {
+	x.st.actions.push(withGroup(" ", append([]any{"alter column", ident{value: x.name}}, q...)...))
+	return x
+}
+
+func (x *alterColumn) Type(typ string) AlterColumn {
+	return x.push("type", typ)
+}
+
+func (x *alterColumn) SetDefault(value any) AlterColumn {
+	return x.push("set default", Arg(value))
+}
+
+func (x *alterColumn) DropDefault() AlterColumn {
+	return x.push("drop default")
+}
+
+func (x *alterColumn) SetNotNull() AlterColumn {
+	return x.push("set not null")
+}
+
+func (x *alterColumn) DropNotNull() AlterColumn {
+	return x.push("drop not null")
+}
+
+type drop struct {
+	kind     string
+	name     string
+	ifExists bool
+	cascade  bool
+}
+
+func (st *drop) IfExists() Drop {
+	st.ifExists = true
+	return st
+}
+
+func (st *drop) Cascade() Drop {
+	st.cascade = true
+	return st
+}
+
+func (st *drop) build() []any {
+	var b buffer
+	b.push(st.kind)
+	if st.ifExists {
+		b.push("if exists")
+	}
+	b.push(ident{value: st.name})
+	if st.cascade {
+		b.push("cascade")
+	}
+	return b.q
+}
+
+// DropTable builds drop table statement
+func DropTable(f func(b DropTableStatement)) *Result {
+	var st dropTableStmt
+	f(&st)
+	return newResult(newDDL(st.make()))
+}
+
+// DropTableStatement is the drop table statement builder
+type DropTableStatement interface {
+	Table(table ...string)
+	IfExists()
+	Cascade()
+}
+
+type dropTableStmt struct {
+	tables   group
+	ifExists bool
+	cascade  bool
+}
+
+func (st *dropTableStmt) Table(table ...string) {
+	st.tables.pushIdent(table...)
+}
+
+func (st *dropTableStmt) IfExists() {
+	st.ifExists = true
+}
+
+func (st *dropTableStmt) Cascade() {
+	st.cascade = true
+}
+
+func (st *dropTableStmt) make() *buffer {
+	var b buffer
+	b.push("drop table")
+	if st.ifExists {
+		b.push("if exists")
+	}
+	b.push(&st.tables)
+	if st.cascade {
+		b.push("cascade")
+	}
+	return &b
+}
@@ -0,0 +1,256 @@
+package pgstmt_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql"
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestDDL(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		result *pgstmt.Result
+		query  string
+	}{
+		{
+			"create table",
+			pgstmt.CreateTable(func(b pgstmt.CreateTableStatement) {
+				b.Table("public.orders")
+				b.IfNotExists()
+				b.Column("id", "bigint").PrimaryKey()
+				b.Column("user_id", "bigint").NotNull().References("users", "id").OnDelete("cascade")
+				b.Column("status", "text").NotNull().Default("pending")
+				b.Column("price", "numeric(10, 2)").Check(func(b pgstmt.Cond) {
+					b.Gt("price", 0)
+				})
+				b.Column("created_at", "timestamptz").NotNull().Default(pgstmt.Raw("now()"))
+				b.Constraint("orders_user_status_key").Unique("user_id", "status")
+			}),
+			`
+				create table if not exists "public"."orders" (
+					"id" bigint primary key,
+					"user_id" bigint not null references "users" ("id") on delete cascade,
+					"status" text not null default 'pending',
+					"price" numeric(10, 2) check (price > 0),
+					"created_at" timestamptz not null default now(),
+					constraint "orders_user_status_key" unique ("user_id", "status")
+				)
+			`,
+		},
+		{
+			"create table fold case",
+			pgstmt.CreateTable(func(b pgstmt.CreateTableStatement) {
+				b.Table("Users")
+				b.Column("userId", "bigint")
+				b.Column(`"displayName"`, "text")
+			}),
+			`create table "users" ("userid" bigint, "displayName" text)`,
+		},
+		{
+			"create partitioned table",
+			pgstmt.CreateTable(func(b pgstmt.CreateTableStatement) {
+				b.Table("events")
+				b.Column("id", "bigint")
+				b.Column("created_at", "timestamptz")
+				b.Constraint("").ForeignKey("id").References("event_ids")
+				b.PartitionBy("range", "created_at")
+			}),
+			`
+				create table "events" (
+					"id" bigint,
+					"created_at" timestamptz,
+					foreign key ("id") references "event_ids"
+				) partition by range ("created_at")
+			`,
+		},
+		{
+			"create partition",
+			pgstmt.CreateTable(func(b pgstmt.CreateTableStatement) {
+				b.Table("events_2024_01")
+				b.IfNotExists()
+				b.PartitionOf("events").ForValuesFrom("2024-01-01").To("2024-02-01")
+			}),
+			`
+				create table if not exists "events_2024_01" partition of "events"
+				for values from ('2024-01-01') to ('2024-02-01')
+			`,
+		},
+		{
+			"create partition with",
+			pgstmt.CreateTable(func(b pgstmt.CreateTableStatement) {
+				b.Table("users_p0")
+				b.PartitionOf("users").ForValuesWith(4, 0)
+			}),
+			`create table "users_p0" partition of "users" for values with (modulus 4, remainder 0)`,
+		},
+		{
+			"alter table",
+			pgstmt.AlterTable(func(b pgstmt.AlterTableStatement) {
+				b.Table("users")
+				b.IfExists()
+				b.AddColumnIfNotExists("age", "int").NotNull().Default(0)
+				b.AlterColumn("name").Type("varchar(100)").SetNotNull()
+				b.DropColumn("nickname").IfExists().Cascade()
+				b.AddConstraint("users_age_check").Check(func(b pgstmt.Cond) {
+					b.Ge("age", 0)
+					b.Lt("age", 200)
+				})
+				b.DropConstraint("users_old_check").IfExists()
+			}),
+			`
+				alter table if exists "users"
+				add column if not exists "age" int not null default 0,
+				alter column "name" type varchar(100),
+				alter column "name" set not null,
+				drop column if exists "nickname" cascade,
+				add constraint "users_age_check" check (age >= 0 and age < 200),
+				drop constraint if exists "users_old_check"
+			`,
+		},
+		{
+			"attach partition",
+			pgstmt.AlterTable(func(b pgstmt.AlterTableStatement) {
+				b.Table("orders")
+				b.AttachPartition("orders_th").ForValuesIn("TH", "LA")
+			}),
+			`alter table "orders" attach partition "orders_th" for values in ('TH', 'LA')`,
+		},
+		{
+			"rename",
+			pgstmt.AlterTable(func(b pgstmt.AlterTableStatement) {
+				b.Table("users")
+				b.RenameTo("members")
+			}),
+			`alter table "users" rename to "members"`,
+		},
+		{
+			"drop table",
+			pgstmt.DropTable(func(b pgstmt.DropTableStatement) {
+				b.Table("events_2024_01", "tenant_1.events")
+				b.IfExists()
+				b.Cascade()
+			}),
+			`drop table if exists "events_2024_01", "tenant_1"."events" cascade`,
+		},
+		{
+			"create index",
+			pgstmt.CreateIndex(func(b pgstmt.CreateIndexStatement) {
+				b.Name("users_email_idx")
+				b.Unique()
+				b.Concurrently()
+				b.IfNotExists()
+				b.On("users")
+				b.Column(pgstmt.Raw("lower(email)"))
+				b.Column("created_at").Desc()
+				b.Include("name")
+				b.Where(func(b pgstmt.Cond) {
+					b.IsNull("deleted_at")
+					b.Eq("status", "active")
+				})
+			}),
+			`
+				create unique index concurrently if not exists "users_email_idx" on "users"
+				((lower(email)), "created_at" desc)
+				include ("name")
+				where (deleted_at is null and status = 'active')
+			`,
+		},
+		{
+			"create index using",
+			pgstmt.CreateIndex(func(b pgstmt.CreateIndexStatement) {
+				b.On("posts")
+				b.Using("gin")
+				b.Columns("tags")
+			}),
+			`create index on "posts" using gin ("tags")`,
+		},
+		{
+			"create schema",
+			pgstmt.CreateSchema(func(b pgstmt.CreateSchemaStatement) {
+				b.Schema("tenant_1")
+				b.IfNotExists()
+				b.Authorization("app")
+			}),
+			`create schema if not exists "tenant_1" authorization "app"`,
+		},
+		{
+			"quote invalid identifier",
+			pgstmt.CreateSchema(func(b pgstmt.CreateSchemaStatement) {
+				b.Schema(`tenant"; drop table users; --`)
+			}),
+			`create schema "tenant""; drop table users; --"`,
+		},
+		{
+			"literals",
+			pgstmt.CreateTable(func(b pgstmt.CreateTableStatement) {
+				b.Table("t")
+				b.Column("a", "text").Default("it's")
+				b.Column("b", "bytea").Default([]byte{0xde, 0xad})
+				b.Column("c", "jsonb").Default(pgsql.JSON(map[string]any{"x": 1}))
+				b.Column("d", "float8").Default(math.Inf(1))
+				b.Column("e", "bigint").Default(uint8(7))
+				b.Column("f", "text").Default((*string)(nil))
+				b.Column("g", "timestamptz").Default(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+			}),
+			`create table "t" (
+				"a" text default 'it''s',
+				"b" bytea default '\xdead'::bytea,
+				"c" jsonb default '{"x":1}',
+				"d" float8 default '+Inf',
+				"e" bigint default 7,
+				"f" text default null,
+				"g" timestamptz default '2020-01-02 03:04:05Z'
+			)`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NoError(t, tc.result.Err())
+			q, args := tc.result.SQL()
+			assert.Equal(t, stripSpace(tc.query), q)
+			assert.Empty(t, args)
+		})
+	}
+
+	t.Run("invalid literal", func(t *testing.T) {
+		for name, v := range map[string]any{
+			"named":  pgstmt.Named("x"),
+			"valuer": errValuer{},
+			"struct": struct{}{},
+			"slice":  []int{1},
+		} {
+			r := pgstmt.CreateIndex(func(b pgstmt.CreateIndexStatement) {
+				b.On("t")
+				b.Columns("a")
+				b.Where(func(b pgstmt.Cond) {
+					b.Eq("a", v)
+				})
+			})
+			assert.Error(t, r.Err(), name)
+
+			_, err := r.ExecContext(context.Background(), func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+				assert.Fail(t, "should not execute", name)
+				return nil, nil
+			})
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+type errValuer struct{}
+
+func (errValuer) Value() (driver.Value, error) {
+	return nil, fmt.Errorf("value error")
+}
@@ -0,0 +1,72 @@
+package pgstmt
+
+import (
+	"github.com/lib/pq"
+)
+
+// TextQueryMode is the function to parse full-text search query
+type TextQueryMode int
+
+const (
+	PlainQuery     TextQueryMode = iota // plainto_tsquery
+	PhraseQuery                         // phraseto_tsquery
+	WebSearchQuery                      // websearch_to_tsquery
+	RawQuery                            // to_tsquery
+)
+
+func (m TextQueryMode) function() string {
+	switch m {
+	case PhraseQuery:
+		return "phraseto_tsquery"
+	case WebSearchQuery:
+		return "websearch_to_tsquery"
+	case RawQuery:
+		return "to_tsquery"
+	default:
+		return "plainto_tsquery"
+	}
+}
+
+// TextQuery is the full-text search query,
+// renders as websearch_to_tsquery('english', $1)
+type TextQuery struct {
+	Config string // text search config, ex. english, empty to use default_text_search_config
+	Mode   TextQueryMode
+	Text   string // search text, will be bind as argument
+}
+
+func (q TextQuery) build() []any {
+	return []any{concat(q.Mode.function(), "(", configArg(q.Config), Arg(q.Text), ")")}
+}
+
+func configArg(config string) any {
+	if config == "" {
+		return ""
+	}
+	return concat(pq.QuoteLiteral(config), ", ")
+}
+
+// ToTSVector builds to_tsvector('config', document) expression
+func ToTSVector(config string, document any) any {
+	return concat("to_tsvector(", configArg(config), document, ")")
+}
+
+// TSRank builds ts_rank(document, query) expression,
+// document must be tsvector, use with Columns or OrderBy for ranking.
+func TSRank(document any, query TextQuery) any {
+	return concat("ts_rank(", document, ", ", query, ")")
+}
+
+// TSHeadline builds ts_headline('config', document, query, 'options') expression,
+// document must be text, options can be empty (ex. "StartSel=<b>, StopSel=</b>").
+func TSHeadline(document any, query TextQuery, options string) any {
+	var opt any = ""
+	if options != "" {
+		opt = concat(", ", pq.QuoteLiteral(options))
+	}
+	return concat("ts_headline(", configArg(query.Config), document, ", ", query, opt, ")")
+}
+
+func (st *cond) Match(document any, query TextQuery) {
+	st.op(document, "@@", query)
+}
@@ -0,0 +1,59 @@
+package pgstmt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestTextSearch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("websearch", func(t *testing.T) {
+		query := pgstmt.TextQuery{
+			Config: "english",
+			Mode:   pgstmt.WebSearchQuery,
+			Text:   `"quick fox" -dog`,
+		}
+
+		q, args := pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("id", pgstmt.TSHeadline("body", query, "StartSel=<b>, StopSel=</b>"))
+			b.From("posts")
+			b.Where(func(b pgstmt.Cond) {
+				b.Match(pgstmt.ToTSVector("english", "body"), query)
+			})
+			b.OrderByExpr(pgstmt.TSRank("search_vector", query)).Desc()
+		}).SQL()
+
+		assert.Equal(t, stripSpace(`
+			select id, ts_headline('english', body, websearch_to_tsquery('english', $1), 'StartSel=<b>, StopSel=</b>')
+			from posts
+			where (to_tsvector('english', body) @@ websearch_to_tsquery('english', $2))
+			order by ts_rank(search_vector, websearch_to_tsquery('english', $3)) desc
+		`), q)
+		assert.EqualValues(t, []any{query.Text, query.Text, query.Text}, args)
+	})
+
+	t.Run("modes", func(t *testing.T) {
+		q, args := pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("id")
+			b.From("posts")
+			b.Where(func(b pgstmt.Cond) {
+				b.Match("v", pgstmt.TextQuery{Text: "a"})
+				b.Match("v", pgstmt.TextQuery{Mode: pgstmt.PhraseQuery, Text: "b"})
+				b.Match("v", pgstmt.TextQuery{Mode: pgstmt.RawQuery, Config: "simple", Text: "c & d"})
+			})
+		}).SQL()
+
+		assert.Equal(t, stripSpace(`
+			select id
+			from posts
+			where (v @@ plainto_tsquery($1)
+				and v @@ phraseto_tsquery($2)
+				and v @@ to_tsquery('simple', $3))
+		`), q)
+		assert.EqualValues(t, []any{"a", "b", "c & d"}, args)
+	})
+}
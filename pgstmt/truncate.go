@@ -0,0 +1,61 @@
+package pgstmt
+
+// Truncate builds truncate statement
+func Truncate(f func(b TruncateStatement)) *Result {
+	var st truncateStmt
+	f(&st)
+	return newResult(st.make())
+}
+
+// TruncateStatement is the truncate statement builder
+type TruncateStatement interface {
+	Table(table ...string)
+
+	// Only truncates tables without their descendant tables
+	Only(table ...string)
+
+	RestartIdentity()
+	Cascade()
+}
+
+type truncateStmt struct {
+	tables          group
+	names           []ident
+	restartIdentity bool
+	cascade         bool
+}
+
+func (st *truncateStmt) Table(table ...string) {
+	st.tables.pushIdent(table...)
+	for _, x := range table {
+		st.names = append(st.names, ident{value: x})
+	}
+}
+
+func (st *truncateStmt) Only(table ...string) {
+	for _, x := range table {
+		st.tables.push(withGroup(" ", "only", ident{value: x}))
+		st.names = append(st.names, ident{value: x})
+	}
+}
+
+func (st *truncateStmt) RestartIdentity() {
+	st.restartIdentity = true
+}
+
+func (st *truncateStmt) Cascade() {
+	st.cascade = true
+}
+
+func (st *truncateStmt) make() *buffer {
+	var b buffer
+	b.push(&scopeDeny{"truncate", st.names})
+	b.push("truncate", &st.tables)
+	if st.restartIdentity {
+		b.push("restart identity")
+	}
+	if st.cascade {
+		b.push("cascade")
+	}
+	return &b
+}
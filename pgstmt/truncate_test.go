@@ -0,0 +1,44 @@
+package pgstmt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("single", func(t *testing.T) {
+		q, args := pgstmt.Truncate(func(b pgstmt.TruncateStatement) {
+			b.Table("users")
+		}).SQL()
+
+		assert.Equal(t, "truncate users", q)
+		assert.Empty(t, args)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		q, args := pgstmt.Truncate(func(b pgstmt.TruncateStatement) {
+			b.Table("users", "orders")
+			b.Only("events")
+			b.RestartIdentity()
+			b.Cascade()
+		}).SQL()
+
+		assert.Equal(t, "truncate users, orders, only events restart identity cascade", q)
+		assert.Empty(t, args)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		r, err := pgstmt.Strict(pgstmt.Truncate(func(b pgstmt.TruncateStatement) {
+			b.Table("public.users")
+			b.Only("events")
+		}))
+		assert.NoError(t, err)
+		q, _ := r.SQL()
+		assert.Equal(t, `truncate "public"."users", only "events"`, q)
+	})
+}
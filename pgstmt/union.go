@@ -3,7 +3,7 @@ package pgstmt
 func Union(f func(b UnionStatement)) *Result {
 	var st unionStmt
 	f(&st)
-	return newResult(build(st.make()))
+	return newResult(st.make())
 }
 
 type UnionStatement interface {
@@ -11,16 +11,23 @@ type UnionStatement interface {
 	AllSelect(f func(b SelectStatement))
 	Union(f func(b UnionStatement))
 	AllUnion(f func(b UnionStatement))
+	Values(f func(b Values))
+	AllValues(f func(b Values))
 	OrderBy(col string) OrderBy
+	OrderByExpr(col any) OrderBy
 	Limit(n int64)
+	LimitArg(n int64)
+	LimitAll()
 	Offset(n int64)
+	OffsetArg(n int64)
+	FetchFirst(n int64) Fetch
+	FetchFirstArg(n int64) Fetch
 }
 
 type unionStmt struct {
 	b       buffer
 	orderBy group
-	limit   *int64
-	offset  *int64
+	limitOffset
 }
 
 func (st *unionStmt) Select(f func(b SelectStatement)) {
@@ -67,7 +74,34 @@ func (st *unionStmt) AllUnion(f func(b UnionStatement)) {
 	}
 }
 
+func (st *unionStmt) Values(f func(b Values)) {
+	var x values
+	f(&x)
+
+	if st.b.empty() {
+		st.b.push(paren(x.make()))
+	} else {
+		st.b.push("union", paren(x.make()))
+	}
+}
+
+func (st *unionStmt) AllValues(f func(b Values)) {
+	var x values
+	f(&x)
+
+	if st.b.empty() {
+		st.b.push(paren(x.make()))
+	} else {
+		st.b.push("union all", paren(x.make()))
+	}
+}
+
 func (st *unionStmt) OrderBy(col string) OrderBy {
+	return st.OrderByExpr(col)
+}
+
+// OrderByExpr adds order by expression, ex. Expr, Case
+func (st *unionStmt) OrderByExpr(col any) OrderBy {
 	p := orderBy{
 		col: col,
 	}
@@ -75,25 +109,12 @@ func (st *unionStmt) OrderBy(col string) OrderBy {
 	return &p
 }
 
-func (st *unionStmt) Limit(n int64) {
-	st.limit = &n
-}
-
-func (st *unionStmt) Offset(n int64) {
-	st.offset = &n
-}
-
 func (st *unionStmt) make() *buffer {
 	var b buffer
 	b.push(&st.b)
 	if !st.orderBy.empty() {
 		b.push("order by", &st.orderBy)
 	}
-	if st.limit != nil {
-		b.push("limit", *st.limit)
-	}
-	if st.offset != nil {
-		b.push("offset", *st.offset)
-	}
+	st.limitOffset.make(&b)
 	return &b
 }
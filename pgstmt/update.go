@@ -1,16 +1,19 @@
 package pgstmt
 
+import "fmt"
+
 // Update builds update statement
 func Update(f func(b UpdateStatement)) *Result {
 	var st updateStmt
 	f(&st)
-	return newResult(build(st.make()))
+	return newResult(st.make())
 }
 
 type UpdateStatement interface {
 	Table(table string)
 	Set(col ...string) Set
 	From(table ...string)
+	FromValues(f func(b Values), as string)
 	Join(table string) Join
 	InnerJoin(table string) Join
 	FullOuterJoin(table string) Join
@@ -19,6 +22,24 @@ type UpdateStatement interface {
 	Where(f func(b Cond))
 	WhereCurrentOf(cursor string)
 	Returning(col ...string)
+
+	// SetValues updates rows from values list,
+	// builds set col = as.col from (values ...) as as (cols) where table.key = as.key,
+	// statement without rows returns an error
+	SetValues(as string, f func(b UpdateValues))
+}
+
+// UpdateValues is the values list builder for bulk update
+type UpdateValues interface {
+	// Key adds column to match rows, typ is the sql type used to cast values (ex. bigint)
+	Key(col, typ string)
+
+	// Column adds column to update, typ is the sql type used to cast values (ex. text)
+	Column(col, typ string)
+
+	// Value adds a row, values are in the same order as Key and Column
+	Value(value ...any)
+	Values(values ...[]any)
 }
 
 type Set interface {
@@ -35,6 +56,7 @@ type updateStmt struct {
 	where          cond
 	whereCurrentOf string
 	returning      group
+	values         *updateValues
 }
 
 func (st *updateStmt) Table(table string) {
@@ -43,18 +65,34 @@ func (st *updateStmt) Table(table string) {
 
 func (st *updateStmt) Set(col ...string) Set {
 	var x set
-	x.col.pushString(col...)
+	x.col.pushIdent(col...)
 	st.sets.push(&x)
 	return &x
 }
 
 func (st *updateStmt) From(table ...string) {
-	st.from.pushString(table...)
+	st.from.pushTable(table...)
+}
+
+func (st *updateStmt) FromValues(f func(b Values), as string) {
+	var x values
+	f(&x)
+
+	if x.empty() {
+		return
+	}
+	st.from.push(x.from(as))
+}
+
+func (st *updateStmt) SetValues(as string, f func(b UpdateValues)) {
+	x := updateValues{as: as}
+	f(&x)
+	st.values = &x
 }
 
 func (st *updateStmt) join(typ, table string) Join {
 	var b buffer
-	b.push(table)
+	b.push(ident{value: table, alias: true})
 	x := join{
 		typ:   typ,
 		table: &b,
@@ -92,14 +130,18 @@ func (st *updateStmt) WhereCurrentOf(cursor string) {
 }
 
 func (st *updateStmt) Returning(col ...string) {
-	st.returning.pushString(col...)
+	st.returning.pushIdent(col...)
 }
 
 func (st *updateStmt) make() *buffer {
+	if st.values != nil {
+		return st.setValues()
+	}
+
 	var b buffer
 	b.push("update")
 	if st.table != "" {
-		b.push(st.table)
+		b.push(ident{value: st.table, alias: true})
 	}
 	if !st.sets.empty() {
 		b.push("set", &st.sets)
@@ -110,10 +152,12 @@ func (st *updateStmt) make() *buffer {
 	if !st.joins.empty() {
 		b.push(&st.joins)
 	}
-	if !st.where.empty() {
-		b.push("where", &st.where)
+	tables := scopeTables([]any{ident{value: st.table, alias: true}}, st.from.q, usingJoinTables(st.joins.q))
+	if len(tables) > 0 && st.whereCurrentOf == "" || !st.where.empty() {
+		b.push(&scopeCond{"where", tables, &st.where})
 	}
 	if st.whereCurrentOf != "" {
+		b.push(&scopeDeny{"update where current of", tables})
 		b.push("where current of", st.whereCurrentOf)
 	}
 	if !st.returning.empty() {
@@ -161,3 +205,77 @@ func (st *set) build() []any {
 	}
 	return b.q
 }
+
+type updateValues struct {
+	as      string
+	columns []updateValuesColumn
+	rows    values
+}
+
+type updateValuesColumn struct {
+	name string
+	typ  string
+	key  bool
+}
+
+func (st *updateValues) Key(col, typ string) {
+	st.columns = append(st.columns, updateValuesColumn{col, typ, true})
+}
+
+func (st *updateValues) Column(col, typ string) {
+	st.columns = append(st.columns, updateValuesColumn{col, typ, false})
+}
+
+func (st *updateValues) Value(value ...any) {
+	xs := make([]any, len(value))
+	for i, v := range value {
+		xs[i] = Arg(v)
+		if i < len(st.columns) && st.columns[i].typ != "" {
+			// cast all values, parameters in values list can not infer type from target column
+			xs[i] = concat(xs[i], "::"+st.columns[i].typ)
+		}
+	}
+	st.rows.Value(xs...)
+}
+
+func (st *updateValues) Values(values ...[]any) {
+	for _, value := range values {
+		st.Value(value...)
+	}
+}
+
+// setValues builds update statement from values list
+func (st *updateStmt) setValues() *buffer {
+	if st.values.rows.empty() {
+		// update without values list would update all matched rows
+		x := *st
+		x.values = nil
+		b := x.make()
+		b.push(buildError{fmt.Errorf("pgstmt: set values %s without rows", st.values.as)})
+		return b
+	}
+
+	x := *st
+	x.values = nil
+	x.sets = st.sets.clone()
+	x.from = st.from.clone()
+	x.where = st.where.clone()
+
+	table := st.table
+	if _, alias, ok := parseTable(table); ok && alias != "" {
+		table = alias
+	}
+
+	as := st.values.as
+	var cols []string
+	for _, c := range st.values.columns {
+		cols = append(cols, c.name)
+		if c.key {
+			x.where = x.where.and(ident{value: table + "." + c.name}, "=", ident{value: as + "." + c.name})
+		} else {
+			x.Set(c.name).ToRaw(ident{value: as + "." + c.name})
+		}
+	}
+	x.from.push(st.values.rows.from(withGroup(" ", "as", ident{value: as}, parenIdent(cols...))))
+	return x.make()
+}
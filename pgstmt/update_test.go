@@ -119,3 +119,145 @@ func TestUpdate(t *testing.T) {
 		)
 	})
 }
+
+func TestUpdate_SetValues(t *testing.T) {
+	t.Parallel()
+
+	t.Run("values", func(t *testing.T) {
+		q, args := pgstmt.Update(func(b pgstmt.UpdateStatement) {
+			b.Table("users")
+			b.SetValues("v", func(b pgstmt.UpdateValues) {
+				b.Key("id", "bigint")
+				b.Column("name", "text")
+				b.Column("age", "int")
+				b.Value(1, "name1", 20)
+				b.Value(2, "name2", 30)
+			})
+			b.Set("updated_at").ToRaw("now()")
+			b.Where(func(b pgstmt.Cond) {
+				b.Eq("users.active", true)
+			})
+			b.Returning("users.id")
+		}).SQL()
+
+		assert.Equal(t,
+			stripSpace(`
+				update users
+				set updated_at = now(),
+					name = v.name,
+					age = v.age
+				from (values ($1::bigint, $2::text, $3::int), ($4::bigint, $5::text, $6::int)) as v (id, name, age)
+				where (users.active = $7 and users.id = v.id)
+				returning users.id
+			`),
+			q,
+		)
+		assert.EqualValues(t,
+			[]any{
+				1, "name1", 20,
+				2, "name2", 30,
+				true,
+			},
+			args,
+		)
+	})
+
+	t.Run("alias", func(t *testing.T) {
+		q, args := pgstmt.Update(func(b pgstmt.UpdateStatement) {
+			b.Table("users u")
+			b.SetValues("v", func(b pgstmt.UpdateValues) {
+				b.Key("tenant_id", "bigint")
+				b.Key("id", "bigint")
+				b.Column("score", "")
+				b.Values(
+					[]any{1, 2, 10},
+					[]any{1, 3, 20},
+				)
+			})
+		}).SQL()
+
+		assert.Equal(t,
+			stripSpace(`
+				update users u
+				set score = v.score
+				from (values ($1::bigint, $2::bigint, $3), ($4::bigint, $5::bigint, $6)) as v (tenant_id, id, score)
+				where (u.tenant_id = v.tenant_id and u.id = v.id)
+			`),
+			q,
+		)
+		assert.EqualValues(t,
+			[]any{
+				1, 2, 10,
+				1, 3, 20,
+			},
+			args,
+		)
+	})
+
+	t.Run("or where", func(t *testing.T) {
+		q, args := pgstmt.Update(func(b pgstmt.UpdateStatement) {
+			b.Table("users")
+			b.SetValues("v", func(b pgstmt.UpdateValues) {
+				b.Key("id", "bigint")
+				b.Column("name", "text")
+				b.Value(1, "name1")
+			})
+			b.Where(func(b pgstmt.Cond) {
+				b.Mode().Or()
+				b.Eq("users.active", true)
+				b.IsNull("users.name")
+			})
+		}).SQL()
+
+		assert.Equal(t,
+			stripSpace(`
+				update users
+				set name = v.name
+				from (values ($1::bigint, $2::text)) as v (id, name)
+				where ((users.active = $3 or users.name is null) and users.id = v.id)
+			`),
+			q,
+		)
+		assert.EqualValues(t, []any{1, "name1", true}, args)
+	})
+
+	t.Run("empty values", func(t *testing.T) {
+		r := pgstmt.Update(func(b pgstmt.UpdateStatement) {
+			b.Table("users")
+			b.SetValues("v", func(b pgstmt.UpdateValues) {
+				b.Key("id", "bigint")
+				b.Column("name", "text")
+			})
+		})
+		assert.Error(t, r.Err())
+	})
+
+	t.Run("from values", func(t *testing.T) {
+		q, args := pgstmt.Update(func(b pgstmt.UpdateStatement) {
+			b.Table("users")
+			b.Set("name").ToRaw("v.name")
+			b.FromValues(func(b pgstmt.Values) {
+				b.Value(1, "name1")
+			}, "v (id, name)")
+			b.Where(func(b pgstmt.Cond) {
+				b.EqRaw("users.id", "v.id")
+			})
+		}).SQL()
+
+		assert.Equal(t,
+			stripSpace(`
+				update users
+				set name = v.name
+				from (values ($1, $2)) v (id, name)
+				where (users.id = v.id)
+			`),
+			q,
+		)
+		assert.EqualValues(t,
+			[]any{
+				1, "name1",
+			},
+			args,
+		)
+	})
+}
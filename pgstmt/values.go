@@ -0,0 +1,53 @@
+package pgstmt
+
+// ValuesList builds values statement, ex. values (1, 'a'), (2, 'b')
+func ValuesList(f func(b Values)) *Result {
+	var st values
+	f(&st)
+	return newResult(st.make())
+}
+
+type values struct {
+	group
+}
+
+func (st *values) Value(value ...any) {
+	var x parenGroup
+	for _, v := range value {
+		x.push(Arg(v))
+	}
+	st.push(&x)
+}
+
+func (st *values) Values(values ...[]any) {
+	for _, value := range values {
+		st.Value(value...)
+	}
+}
+
+// from returns values as from item
+func (st *values) from(as any) any {
+	return withGroup(" ",
+		withGroup(" ",
+			withParen(" ",
+				"values",
+				withGroup(", ", st.q...),
+			),
+		),
+		as,
+	)
+}
+
+func (st *values) make() *buffer {
+	var b buffer
+	b.push("values", &st.group)
+	return &b
+}
+
+// Table builds table statement, same as select * from table,
+// scoped table is built as select * from table where ...
+func Table(table string) *Result {
+	var b buffer
+	b.push(&scopeTable{table})
+	return newResult(&b)
+}
@@ -0,0 +1,63 @@
+package pgstmt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		result *pgstmt.Result
+		query  string
+		args   []any
+	}{
+		{
+			"values",
+			pgstmt.ValuesList(func(b pgstmt.Values) {
+				b.Value(1, "a")
+				b.Values([]any{2, "b"}, []any{3, pgstmt.Default})
+			}),
+			"values ($1, $2), ($3, $4), ($5, default)",
+			[]any{1, "a", 2, "b", 3},
+		},
+		{
+			"union member",
+			pgstmt.Union(func(b pgstmt.UnionStatement) {
+				b.Select(func(b pgstmt.SelectStatement) {
+					b.Columns("id", "name")
+					b.From("users")
+				})
+				b.AllValues(func(b pgstmt.Values) {
+					b.Value(0, "guest")
+				})
+				b.OrderBy("id")
+			}),
+			`
+				(select id, name from users)
+				union all (values ($1, $2))
+				order by id
+			`,
+			[]any{0, "guest"},
+		},
+		{
+			"table",
+			pgstmt.Table("public.users"),
+			"table public.users",
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, args := tc.result.SQL()
+			assert.Equal(t, stripSpace(tc.query), q)
+			assert.EqualValues(t, tc.args, args)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package pgstmt
+
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
+// isZero checks is value zero, same as pgsql.Null,
+// nil pointer, pointer to zero value, zero value, IsZero() and valuer of null are zero
+func isZero(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return true
+	}
+	if z, ok := v.(interface{ IsZero() bool }); ok {
+		return z.IsZero()
+	}
+	if x, ok := v.(driver.Valuer); ok {
+		p, err := x.Value()
+		return err == nil && p == nil
+	}
+	if rv.Kind() == reflect.Ptr {
+		return isZero(rv.Elem().Interface())
+	}
+	return rv.IsZero()
+}
+
+func (st *cond) EqIfNotZero(field, value any) {
+	if !isZero(value) {
+		st.Eq(field, value)
+	}
+}
+
+func (st *cond) NeIfNotZero(field, value any) {
+	if !isZero(value) {
+		st.Ne(field, value)
+	}
+}
+
+func (st *cond) LtIfNotZero(field, value any) {
+	if !isZero(value) {
+		st.Lt(field, value)
+	}
+}
+
+func (st *cond) LeIfNotZero(field, value any) {
+	if !isZero(value) {
+		st.Le(field, value)
+	}
+}
+
+func (st *cond) GtIfNotZero(field, value any) {
+	if !isZero(value) {
+		st.Gt(field, value)
+	}
+}
+
+func (st *cond) GeIfNotZero(field, value any) {
+	if !isZero(value) {
+		st.Ge(field, value)
+	}
+}
+
+func (st *cond) LikeIfNotZero(field, value any) {
+	if !isZero(value) {
+		st.Like(field, value)
+	}
+}
+
+func (st *cond) ILikeIfNotZero(field, value any) {
+	if !isZero(value) {
+		st.ILike(field, value)
+	}
+}
+
+func (st *cond) InIfNotEmpty(field any, value ...any) {
+	if len(value) > 0 {
+		st.In(field, value...)
+	}
+}
+
+func (st *cond) NotInIfNotEmpty(field any, value ...any) {
+	if len(value) > 0 {
+		st.NotIn(field, value...)
+	}
+}
+
+func (st *cond) When(ok bool, f func(b Cond)) {
+	if ok {
+		f(st)
+	}
+}
+
+func (st *selectStmt) When(ok bool, f func(b SelectStatement)) {
+	if ok {
+		f(st)
+	}
+}
@@ -0,0 +1,102 @@
+package pgstmt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/acoshift/pgsql"
+	"github.com/acoshift/pgsql/pgstmt"
+)
+
+func TestWhen(t *testing.T) {
+	t.Parallel()
+
+	type filter struct {
+		Name      string
+		MinAge    int
+		Status    *string
+		After     time.Time
+		Tags      []any
+		Deleted   bool
+		Paginate  bool
+		Reference string
+	}
+
+	build := func(f filter) (string, []any) {
+		return pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("id")
+			b.From("users")
+			b.Where(func(b pgstmt.Cond) {
+				b.ILikeIfNotZero("name", f.Name)
+				b.GeIfNotZero("age", f.MinAge)
+				b.EqIfNotZero("status", f.Status)
+				b.GtIfNotZero("created_at", f.After)
+				b.InIfNotEmpty("tag", f.Tags...)
+				b.EqIfNotZero("ref", pgsql.NullString(&f.Reference))
+				b.When(!f.Deleted, func(b pgstmt.Cond) {
+					b.IsNull("deleted_at")
+				})
+			})
+			b.When(f.Paginate, func(b pgstmt.SelectStatement) {
+				b.OrderBy("id")
+				b.Limit(10)
+			})
+		}).SQL()
+	}
+
+	t.Run("Zero", func(t *testing.T) {
+		q, args := build(filter{})
+		assert.Equal(t, "select id from users where (deleted_at is null)", q)
+		assert.Empty(t, args)
+	})
+
+	t.Run("Not zero", func(t *testing.T) {
+		status := "active"
+		after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		q, args := build(filter{
+			Name:      "%john%",
+			MinAge:    18,
+			Status:    &status,
+			After:     after,
+			Tags:      []any{"a", "b"},
+			Deleted:   true,
+			Paginate:  true,
+			Reference: "x",
+		})
+		assert.Equal(t, stripSpace(`
+			select id
+			from users
+			where (name ilike $1
+				and age >= $2
+				and status = $3
+				and created_at > $4
+				and tag in ($5, $6)
+				and ref = $7)
+			order by id
+			limit 10
+		`), q)
+		assert.Len(t, args, 7)
+		assert.Equal(t, []any{"%john%", 18, &status, after, "a", "b"}, args[:6])
+	})
+
+	t.Run("Pointer to zero", func(t *testing.T) {
+		status := ""
+		q, _ := build(filter{Status: &status, Deleted: true})
+		assert.Equal(t, "select id from users", q)
+	})
+
+	t.Run("Nil pointer", func(t *testing.T) {
+		var after *time.Time
+		q, args := pgstmt.Select(func(b pgstmt.SelectStatement) {
+			b.Columns("id")
+			b.From("users")
+			b.Where(func(b pgstmt.Cond) {
+				b.EqIfNotZero("created_at", after)
+			})
+		}).SQL()
+		assert.Equal(t, "select id from users", q)
+		assert.Empty(t, args)
+	})
+}